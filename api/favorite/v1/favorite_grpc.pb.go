@@ -0,0 +1,395 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: favorite/v1/favorite.proto
+
+package favoritev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	FavoriteService_ListFavoriteLists_FullMethodName     = "/favorite.v1.FavoriteService/ListFavoriteLists"
+	FavoriteService_CreateFavoriteList_FullMethodName    = "/favorite.v1.FavoriteService/CreateFavoriteList"
+	FavoriteService_UpdateFavoriteList_FullMethodName    = "/favorite.v1.FavoriteService/UpdateFavoriteList"
+	FavoriteService_DeleteFavoriteList_FullMethodName    = "/favorite.v1.FavoriteService/DeleteFavoriteList"
+	FavoriteService_GetFavoriteItems_FullMethodName      = "/favorite.v1.FavoriteService/GetFavoriteItems"
+	FavoriteService_AddFavoriteItem_FullMethodName       = "/favorite.v1.FavoriteService/AddFavoriteItem"
+	FavoriteService_RemoveFavoriteItem_FullMethodName    = "/favorite.v1.FavoriteService/RemoveFavoriteItem"
+	FavoriteService_ContainsFavoriteItems_FullMethodName = "/favorite.v1.FavoriteService/ContainsFavoriteItems"
+)
+
+// FavoriteServiceClient is the client API for FavoriteService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// FavoriteService REST API'deki liste ve ürün işlemlerini gRPC üzerinden sunar.
+// Kimlik doğrulama için "authorization" metadata anahtarı REST'teki Authorization
+// header'ı ile aynı token'ı taşır.
+type FavoriteServiceClient interface {
+	ListFavoriteLists(ctx context.Context, in *ListFavoriteListsRequest, opts ...grpc.CallOption) (*ListFavoriteListsResponse, error)
+	CreateFavoriteList(ctx context.Context, in *CreateFavoriteListRequest, opts ...grpc.CallOption) (*FavoriteList, error)
+	UpdateFavoriteList(ctx context.Context, in *UpdateFavoriteListRequest, opts ...grpc.CallOption) (*FavoriteList, error)
+	DeleteFavoriteList(ctx context.Context, in *DeleteFavoriteListRequest, opts ...grpc.CallOption) (*DeleteFavoriteListResponse, error)
+	GetFavoriteItems(ctx context.Context, in *GetFavoriteItemsRequest, opts ...grpc.CallOption) (*GetFavoriteItemsResponse, error)
+	AddFavoriteItem(ctx context.Context, in *AddFavoriteItemRequest, opts ...grpc.CallOption) (*FavoriteItem, error)
+	RemoveFavoriteItem(ctx context.Context, in *RemoveFavoriteItemRequest, opts ...grpc.CallOption) (*RemoveFavoriteItemResponse, error)
+	ContainsFavoriteItems(ctx context.Context, in *ContainsFavoriteItemsRequest, opts ...grpc.CallOption) (*ContainsFavoriteItemsResponse, error)
+}
+
+type favoriteServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFavoriteServiceClient(cc grpc.ClientConnInterface) FavoriteServiceClient {
+	return &favoriteServiceClient{cc}
+}
+
+func (c *favoriteServiceClient) ListFavoriteLists(ctx context.Context, in *ListFavoriteListsRequest, opts ...grpc.CallOption) (*ListFavoriteListsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFavoriteListsResponse)
+	err := c.cc.Invoke(ctx, FavoriteService_ListFavoriteLists_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *favoriteServiceClient) CreateFavoriteList(ctx context.Context, in *CreateFavoriteListRequest, opts ...grpc.CallOption) (*FavoriteList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FavoriteList)
+	err := c.cc.Invoke(ctx, FavoriteService_CreateFavoriteList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *favoriteServiceClient) UpdateFavoriteList(ctx context.Context, in *UpdateFavoriteListRequest, opts ...grpc.CallOption) (*FavoriteList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FavoriteList)
+	err := c.cc.Invoke(ctx, FavoriteService_UpdateFavoriteList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *favoriteServiceClient) DeleteFavoriteList(ctx context.Context, in *DeleteFavoriteListRequest, opts ...grpc.CallOption) (*DeleteFavoriteListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteFavoriteListResponse)
+	err := c.cc.Invoke(ctx, FavoriteService_DeleteFavoriteList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *favoriteServiceClient) GetFavoriteItems(ctx context.Context, in *GetFavoriteItemsRequest, opts ...grpc.CallOption) (*GetFavoriteItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFavoriteItemsResponse)
+	err := c.cc.Invoke(ctx, FavoriteService_GetFavoriteItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *favoriteServiceClient) AddFavoriteItem(ctx context.Context, in *AddFavoriteItemRequest, opts ...grpc.CallOption) (*FavoriteItem, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FavoriteItem)
+	err := c.cc.Invoke(ctx, FavoriteService_AddFavoriteItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *favoriteServiceClient) RemoveFavoriteItem(ctx context.Context, in *RemoveFavoriteItemRequest, opts ...grpc.CallOption) (*RemoveFavoriteItemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveFavoriteItemResponse)
+	err := c.cc.Invoke(ctx, FavoriteService_RemoveFavoriteItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *favoriteServiceClient) ContainsFavoriteItems(ctx context.Context, in *ContainsFavoriteItemsRequest, opts ...grpc.CallOption) (*ContainsFavoriteItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ContainsFavoriteItemsResponse)
+	err := c.cc.Invoke(ctx, FavoriteService_ContainsFavoriteItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FavoriteServiceServer is the server API for FavoriteService service.
+// All implementations must embed UnimplementedFavoriteServiceServer
+// for forward compatibility.
+//
+// FavoriteService REST API'deki liste ve ürün işlemlerini gRPC üzerinden sunar.
+// Kimlik doğrulama için "authorization" metadata anahtarı REST'teki Authorization
+// header'ı ile aynı token'ı taşır.
+type FavoriteServiceServer interface {
+	ListFavoriteLists(context.Context, *ListFavoriteListsRequest) (*ListFavoriteListsResponse, error)
+	CreateFavoriteList(context.Context, *CreateFavoriteListRequest) (*FavoriteList, error)
+	UpdateFavoriteList(context.Context, *UpdateFavoriteListRequest) (*FavoriteList, error)
+	DeleteFavoriteList(context.Context, *DeleteFavoriteListRequest) (*DeleteFavoriteListResponse, error)
+	GetFavoriteItems(context.Context, *GetFavoriteItemsRequest) (*GetFavoriteItemsResponse, error)
+	AddFavoriteItem(context.Context, *AddFavoriteItemRequest) (*FavoriteItem, error)
+	RemoveFavoriteItem(context.Context, *RemoveFavoriteItemRequest) (*RemoveFavoriteItemResponse, error)
+	ContainsFavoriteItems(context.Context, *ContainsFavoriteItemsRequest) (*ContainsFavoriteItemsResponse, error)
+	mustEmbedUnimplementedFavoriteServiceServer()
+}
+
+// UnimplementedFavoriteServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFavoriteServiceServer struct{}
+
+func (UnimplementedFavoriteServiceServer) ListFavoriteLists(context.Context, *ListFavoriteListsRequest) (*ListFavoriteListsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFavoriteLists not implemented")
+}
+func (UnimplementedFavoriteServiceServer) CreateFavoriteList(context.Context, *CreateFavoriteListRequest) (*FavoriteList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFavoriteList not implemented")
+}
+func (UnimplementedFavoriteServiceServer) UpdateFavoriteList(context.Context, *UpdateFavoriteListRequest) (*FavoriteList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFavoriteList not implemented")
+}
+func (UnimplementedFavoriteServiceServer) DeleteFavoriteList(context.Context, *DeleteFavoriteListRequest) (*DeleteFavoriteListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFavoriteList not implemented")
+}
+func (UnimplementedFavoriteServiceServer) GetFavoriteItems(context.Context, *GetFavoriteItemsRequest) (*GetFavoriteItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFavoriteItems not implemented")
+}
+func (UnimplementedFavoriteServiceServer) AddFavoriteItem(context.Context, *AddFavoriteItemRequest) (*FavoriteItem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddFavoriteItem not implemented")
+}
+func (UnimplementedFavoriteServiceServer) RemoveFavoriteItem(context.Context, *RemoveFavoriteItemRequest) (*RemoveFavoriteItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveFavoriteItem not implemented")
+}
+func (UnimplementedFavoriteServiceServer) ContainsFavoriteItems(context.Context, *ContainsFavoriteItemsRequest) (*ContainsFavoriteItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ContainsFavoriteItems not implemented")
+}
+func (UnimplementedFavoriteServiceServer) mustEmbedUnimplementedFavoriteServiceServer() {}
+func (UnimplementedFavoriteServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeFavoriteServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FavoriteServiceServer will
+// result in compilation errors.
+type UnsafeFavoriteServiceServer interface {
+	mustEmbedUnimplementedFavoriteServiceServer()
+}
+
+func RegisterFavoriteServiceServer(s grpc.ServiceRegistrar, srv FavoriteServiceServer) {
+	// If the following call pancis, it indicates UnimplementedFavoriteServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FavoriteService_ServiceDesc, srv)
+}
+
+func _FavoriteService_ListFavoriteLists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFavoriteListsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FavoriteServiceServer).ListFavoriteLists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FavoriteService_ListFavoriteLists_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FavoriteServiceServer).ListFavoriteLists(ctx, req.(*ListFavoriteListsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FavoriteService_CreateFavoriteList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFavoriteListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FavoriteServiceServer).CreateFavoriteList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FavoriteService_CreateFavoriteList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FavoriteServiceServer).CreateFavoriteList(ctx, req.(*CreateFavoriteListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FavoriteService_UpdateFavoriteList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFavoriteListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FavoriteServiceServer).UpdateFavoriteList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FavoriteService_UpdateFavoriteList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FavoriteServiceServer).UpdateFavoriteList(ctx, req.(*UpdateFavoriteListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FavoriteService_DeleteFavoriteList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFavoriteListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FavoriteServiceServer).DeleteFavoriteList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FavoriteService_DeleteFavoriteList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FavoriteServiceServer).DeleteFavoriteList(ctx, req.(*DeleteFavoriteListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FavoriteService_GetFavoriteItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFavoriteItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FavoriteServiceServer).GetFavoriteItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FavoriteService_GetFavoriteItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FavoriteServiceServer).GetFavoriteItems(ctx, req.(*GetFavoriteItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FavoriteService_AddFavoriteItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddFavoriteItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FavoriteServiceServer).AddFavoriteItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FavoriteService_AddFavoriteItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FavoriteServiceServer).AddFavoriteItem(ctx, req.(*AddFavoriteItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FavoriteService_RemoveFavoriteItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveFavoriteItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FavoriteServiceServer).RemoveFavoriteItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FavoriteService_RemoveFavoriteItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FavoriteServiceServer).RemoveFavoriteItem(ctx, req.(*RemoveFavoriteItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FavoriteService_ContainsFavoriteItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContainsFavoriteItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FavoriteServiceServer).ContainsFavoriteItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FavoriteService_ContainsFavoriteItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FavoriteServiceServer).ContainsFavoriteItems(ctx, req.(*ContainsFavoriteItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FavoriteService_ServiceDesc is the grpc.ServiceDesc for FavoriteService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FavoriteService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "favorite.v1.FavoriteService",
+	HandlerType: (*FavoriteServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListFavoriteLists",
+			Handler:    _FavoriteService_ListFavoriteLists_Handler,
+		},
+		{
+			MethodName: "CreateFavoriteList",
+			Handler:    _FavoriteService_CreateFavoriteList_Handler,
+		},
+		{
+			MethodName: "UpdateFavoriteList",
+			Handler:    _FavoriteService_UpdateFavoriteList_Handler,
+		},
+		{
+			MethodName: "DeleteFavoriteList",
+			Handler:    _FavoriteService_DeleteFavoriteList_Handler,
+		},
+		{
+			MethodName: "GetFavoriteItems",
+			Handler:    _FavoriteService_GetFavoriteItems_Handler,
+		},
+		{
+			MethodName: "AddFavoriteItem",
+			Handler:    _FavoriteService_AddFavoriteItem_Handler,
+		},
+		{
+			MethodName: "RemoveFavoriteItem",
+			Handler:    _FavoriteService_RemoveFavoriteItem_Handler,
+		},
+		{
+			MethodName: "ContainsFavoriteItems",
+			Handler:    _FavoriteService_ContainsFavoriteItems_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "favorite/v1/favorite.proto",
+}
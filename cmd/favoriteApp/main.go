@@ -1,15 +1,24 @@
 package main
 
 import (
+	"context"
 	"favorite_service/internal/client"
+	"favorite_service/internal/events"
+	"favorite_service/internal/grpcserver"
 	"favorite_service/internal/handlers"
+	"favorite_service/internal/models"
 	"favorite_service/internal/repositories"
 	"favorite_service/internal/services"
+	"favorite_service/internal/webhooks"
 	"favorite_service/metrics"
 	"favorite_service/pkg/psql"
+	"favorite_service/pkg/ratelimit"
 	"fmt"
 	"log"
+	"net"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-swagno/swagno"
@@ -17,15 +26,15 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/joho/godotenv"
-	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sony/gobreaker"
 )
 
+// legacyApiSunset, versiyonsuz ve /v1 rotalarinin kaldirilacagi tarihtir.
+var legacyApiSunset = time.Date(2027, time.June, 30, 0, 0, 0, 0, time.UTC)
+
 func main() {
 	fmt.Println("Hello Rest")
 
-	app := fiber.New()
-
 	//err := godotenv.Load("../../.env")
 
 	err := godotenv.Load(".env")
@@ -44,6 +53,65 @@ func main() {
 	productServiceURL := os.Getenv("PRODUCT_SERVICE_URL")
 	userServiceURL := os.Getenv("USER_SERVICE_URL")
 
+	internalServiceToken := os.Getenv("INTERNAL_SERVICE_TOKEN")
+
+	eventWebhookURL := os.Getenv("EVENT_WEBHOOK_URL")
+
+	adminToken := os.Getenv("ADMIN_TOKEN")
+
+	// Istemci IP'si yalnizca TRUSTED_PROXIES listesindeki proxy'lerden gelen X-Forwarded-For basligindan okunur;
+	// liste bossa baglantinin uzak adresi kullanilir.
+	appConfig := fiber.Config{}
+
+	if trustedProxies := os.Getenv("TRUSTED_PROXIES"); trustedProxies != "" {
+		appConfig.ProxyHeader = fiber.HeaderXForwardedFor
+		appConfig.EnableTrustedProxyCheck = true
+		appConfig.EnableIPValidation = true
+
+		for _, proxy := range strings.Split(trustedProxies, ",") {
+			if proxy = strings.TrimSpace(proxy); proxy != "" {
+				appConfig.TrustedProxies = append(appConfig.TrustedProxies, proxy)
+			}
+		}
+	}
+
+	app := fiber.New(appConfig)
+
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "9090"
+	}
+
+	productEnrichConcurrency, err := strconv.Atoi(os.Getenv("PRODUCT_ENRICH_CONCURRENCY"))
+	if err != nil || productEnrichConcurrency < 1 {
+		productEnrichConcurrency = 8
+	}
+
+	quotaLimits := models.QuotaLimits{MaxListsPerUser: 50, MaxItemsPerList: 500}
+
+	if maxLists, err := strconv.Atoi(os.Getenv("MAX_LISTS_PER_USER")); err == nil {
+		quotaLimits.MaxListsPerUser = maxLists
+	}
+
+	if maxItems, err := strconv.Atoi(os.Getenv("MAX_ITEMS_PER_LIST")); err == nil {
+		quotaLimits.MaxItemsPerList = maxItems
+	}
+
+	rateLimitPerMinute, err := strconv.Atoi(os.Getenv("RATE_LIMIT_PER_MINUTE"))
+	if err != nil || rateLimitPerMinute < 1 {
+		rateLimitPerMinute = 300
+	}
+
+	rateLimitWritePerMinute, err := strconv.Atoi(os.Getenv("RATE_LIMIT_WRITE_PER_MINUTE"))
+	if err != nil || rateLimitWritePerMinute < 1 {
+		rateLimitWritePerMinute = 60
+	}
+
+	idempotencyTTLHours, err := strconv.Atoi(os.Getenv("IDEMPOTENCY_TTL_HOURS"))
+	if err != nil || idempotencyTTLHours < 1 {
+		idempotencyTTLHours = 24
+	}
+
 	var db = psql.Connect(host, user, password, name, port)
 
 	itemRepository := repositories.NewFavoriteItemRepository(db)
@@ -61,30 +129,160 @@ func main() {
 			return counts.ConsecutiveFailures > 3
 		},
 
-		OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {
-			fmt.Printf("Circuit Breaker '%s' changed from '%s' to '%s'\n", name, from, to)
-		},
+		OnStateChange: client.OnCircuitBreakerStateChange,
 	})
 
+	client.InitCircuitBreakerState(cb.Name(), cb.State())
+
 	userClient := client.NewUserClient(userServiceURL, cb)
 
-	itemService := services.NewFavoriItemService(itemRepository, listRepository, productClient, userClient)
+	// Servisler, RateLimit'in istek context'ine eklediği doğrulanmış kullanıcıyı kullanır.
+	verifiedUserClient := handlers.NewContextUserClient(userClient)
+
+	statsRepository := repositories.NewFavoriteStatsRepository(db)
+
+	favoriteCountService := services.NewFavoriteCountService(statsRepository, time.Minute)
+
+	transactor := psql.NewTransactor(db)
+
+	outboxRepository := repositories.NewOutboxRepository(db)
+
+	var eventPublisher events.Publisher = events.NewLogPublisher()
+	if eventWebhookURL != "" {
+		eventPublisher = events.NewHTTPPublisher(eventWebhookURL, 5*time.Second)
+	}
+
+	webhookRepository := repositories.NewWebhookRepository(db)
+
+	webhookDispatcher := webhooks.NewDispatcher(webhookRepository)
+
+	outboxRelay := events.NewRelay(outboxRepository, events.NewMultiPublisher(eventPublisher, webhookDispatcher), 100, 10, 5*time.Second, 30*time.Minute)
+
+	go outboxRelay.Run(context.Background(), 2*time.Second)
+
+	quotaService := services.NewQuotaService(repositories.NewQuotaRepository(db), verifiedUserClient, quotaLimits)
 
-	listService := services.NewFavoriteListService(listRepository, itemRepository, productClient, userClient)
+	auditRepository := repositories.NewAuditRepository(db)
 
-	histogram := metrics.NewNamedHistogram("http_request_FavoriteListService_duration_seconds", []float64{0.001, 0.005, 0.01, 0.05, 0.1})
+	itemService := services.NewFavoriItemService(itemRepository, listRepository, productClient, verifiedUserClient, favoriteCountService, transactor, outboxRepository, quotaService, auditRepository)
 
-	registry := prometheus.NewRegistry()
-	registry.MustRegister(histogram.Histogram)
+	listService := services.NewFavoriteListService(listRepository, itemRepository, productClient, verifiedUserClient, favoriteCountService, transactor, outboxRepository, productEnrichConcurrency, quotaService, auditRepository)
 
-	itemHandler := handlers.NewFavoriteItemHandler(itemService, histogram)
+	httpMetrics := metrics.NewHTTPMetrics("favorite_service", []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5}, "/metrics")
+
+	statsCollector := metrics.NewFavoriteStatsCollector(statsRepository, time.Minute, 10)
+
+	go statsCollector.Run(context.Background())
+
+	collectors := append(client.Collectors(), metrics.BusinessCollectors()...)
+	grpcMetrics := metrics.NewGRPCMetrics("favorite_service", []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5})
+
+	collectors = append(collectors, metrics.RateLimitCollectors()...)
+
+	collectors = append(collectors, httpMetrics, grpcMetrics, statsCollector)
+
+	registry := metrics.NewRegistry(collectors...)
+
+	app.Use(httpMetrics.Middleware())
+
+	app.Use(handlers.RequestId())
+
+	rateLimitStore := ratelimit.NewMemoryStore()
+
+	go rateLimitStore.RunSweeper(context.Background(), time.Minute)
+
+	writeLimit := ratelimit.Limit{Requests: rateLimitWritePerMinute, Per: time.Minute}
+
+	app.Use(handlers.RateLimit(handlers.RateLimitConfig{
+		Store:      rateLimitStore,
+		UserClient: userClient,
+		Default:    ratelimit.Limit{Requests: rateLimitPerMinute, Per: time.Minute},
+		Rules: []handlers.RateLimitRule{
+			{Name: "write", Method: fiber.MethodPost, Limit: writeLimit},
+			{Name: "write", Method: fiber.MethodPut, Limit: writeLimit},
+			{Name: "write", Method: fiber.MethodPatch, Limit: writeLimit},
+			{Name: "write", Method: fiber.MethodDelete, Limit: writeLimit},
+			{Name: "products", Prefix: "/products", Limit: ratelimit.Limit{Requests: 120, Per: time.Minute}},
+		},
+		SkipPrefixes: []string{"/metrics", "/swagger", "/internal", "/admin"},
+		IdentityTTL:  time.Minute,
+	}))
+
+	idempotencyService := services.NewIdempotencyService(repositories.NewIdempotencyRepository(db), time.Duration(idempotencyTTLHours)*time.Hour, time.Minute)
+
+	go idempotencyService.RunCleaner(context.Background(), time.Hour)
+
+	app.Use(handlers.Idempotency(idempotencyService))
+
+	itemHandler := handlers.NewFavoriteItemHandler(itemService)
 
 	itemHandler.SetRoutes(app)
 
-	listHandler := handlers.NewFavoriteListHandler(listService, histogram)
+	listHandler := handlers.NewFavoriteListHandler(listService)
 
 	listHandler.SetRoutes(app)
 
+	importService := services.NewImportService(listRepository, itemRepository, productClient, verifiedUserClient, favoriteCountService, transactor, outboxRepository, quotaService, auditRepository, productEnrichConcurrency)
+
+	importHandler := handlers.NewImportHandler(importService)
+
+	importHandler.SetRoutes(app)
+
+	// Eski rotalar /v1 altinda da sunulur; istemciler /v2'ye gecene kadar deprecation header'i doner.
+	v1 := app.Group("/v1", handlers.Deprecated(legacyApiSunset, "/v2"))
+
+	itemHandler.SetRoutes(v1)
+
+	listHandler.SetRoutes(v1)
+
+	v2Handler := handlers.NewFavoriteV2Handler(listService, itemService)
+
+	v2Handler.SetRoutes(app.Group("/v2"))
+
+	popularityService := services.NewPopularityService(statsRepository, productClient, 5)
+
+	go popularityService.RunSummaryRefresher(context.Background(), 5*time.Minute)
+
+	productHandler := handlers.NewProductHandler(popularityService)
+
+	productHandler.SetRoutes(app)
+
+	favoriteCountHandler := handlers.NewFavoriteCountHandler(favoriteCountService, internalServiceToken)
+
+	favoriteCountHandler.SetRoutes(app)
+
+	productWatchRepository := repositories.NewProductWatchRepository(db)
+
+	productWatchService := services.NewProductWatchService(productWatchRepository, productClient, verifiedUserClient, 50, 5)
+
+	go productWatchService.RunPoller(context.Background(), 15*time.Minute)
+
+	alertHandler := handlers.NewAlertHandler(productWatchService)
+
+	alertHandler.SetRoutes(app)
+
+	quotaHandler := handlers.NewQuotaHandler(quotaService)
+
+	quotaHandler.SetRoutes(app)
+
+	webhookWorker := webhooks.NewDeliveryWorker(webhookRepository, webhooks.NewSender(5*time.Second), 20, 8, 30*time.Second, 6*time.Hour)
+
+	go webhookWorker.Run(context.Background(), 5*time.Second)
+
+	webhookHandler := handlers.NewWebhookHandler(services.NewWebhookService(webhookRepository), adminToken)
+
+	webhookHandler.SetRoutes(app)
+
+	auditHandler := handlers.NewAuditHandler(services.NewAuditService(auditRepository), adminToken)
+
+	auditHandler.SetRoutes(app)
+
+	privacyService := services.NewPrivacyService(repositories.NewPrivacyRepository(db), listRepository, itemRepository, verifiedUserClient, favoriteCountService, transactor, outboxRepository)
+
+	privacyHandler := handlers.NewPrivacyHandler(privacyService, internalServiceToken)
+
+	privacyHandler.SetRoutes(app)
+
 	app.Get("/metrics", adaptor.HTTPHandler(metrics.GetHandler(registry)))
 
 	sw := swagno.New(swagno.Config{Title: "Testing API", Version: "v1.0.0"})
@@ -93,7 +291,38 @@ func main() {
 
 	sw.AddEndpoints(handlers.ListGetEndpoints())
 
+	sw.AddEndpoints(handlers.ImportGetEndpoints())
+
+	sw.AddEndpoints(handlers.ProductGetEndpoints())
+
+	sw.AddEndpoints(handlers.FavoriteCountGetEndpoints())
+
+	sw.AddEndpoints(handlers.AlertGetEndpoints())
+
+	sw.AddEndpoints(handlers.QuotaGetEndpoints())
+
+	sw.AddEndpoints(handlers.WebhookGetEndpoints())
+
+	sw.AddEndpoints(handlers.AuditGetEndpoints())
+
+	sw.AddEndpoints(handlers.PrivacyGetEndpoints())
+
+	sw.AddEndpoints(handlers.V2GetEndpoints())
+
 	swagger.SwaggerHandler(app, sw.MustToJson(), swagger.WithPrefix("/swagger"))
 
+	grpcServer := grpcserver.NewGRPCServer(grpcserver.NewServer(listService, itemService), grpcMetrics)
+
+	grpcListener, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		log.Fatal("gRPC portu dinlenemedi", err)
+	}
+
+	go func() {
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			log.Fatal("gRPC sunucusu durdu", err)
+		}
+	}()
+
 	log.Fatal(app.Listen(":8080"))
 }
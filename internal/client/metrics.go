@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"favorite_service/logs"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sony/gobreaker"
+)
+
+const (
+	outcomeSuccess     = "success"
+	outcomeTimeout     = "timeout"
+	outcomeClientError = "client_error"
+	outcomeServerError = "server_error"
+	outcomeCircuitOpen = "circuit_open"
+	outcomeError       = "error"
+)
+
+var (
+	upstreamRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "favorite_service",
+		Name:      "upstream_request_duration_seconds",
+		Help:      "User ve product servislerine yapılan isteklerin sonuca göre süresi.",
+		Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+	}, []string{"service", "operation", "outcome"})
+
+	upstreamRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "favorite_service",
+		Name:      "upstream_retries_total",
+		Help:      "Başarısız upstream isteklerinden sonra yapılan tekrar deneme sayısı.",
+	}, []string{"service", "operation"})
+
+	circuitBreakerState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "favorite_service",
+		Name:      "circuit_breaker_state",
+		Help:      "Circuit breaker durumu (0=closed, 1=half-open, 2=open).",
+	}, []string{"name"})
+
+	circuitBreakerTransitions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "favorite_service",
+		Name:      "circuit_breaker_transitions_total",
+		Help:      "Circuit breaker durum geçişlerinin sayısı.",
+	}, []string{"name", "from", "to"})
+)
+
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		upstreamRequestDuration,
+		upstreamRetries,
+		circuitBreakerState,
+		circuitBreakerTransitions,
+	}
+}
+
+type upstreamStatusError struct {
+	statusCode int
+	err        error
+}
+
+func (e *upstreamStatusError) Error() string {
+	return fmt.Sprintf("upstream status %d: %v", e.statusCode, e.err)
+}
+
+func (e *upstreamStatusError) Unwrap() error {
+	return e.err
+}
+
+func observeUpstream(service string, operation string, startTime time.Time, err error) {
+	upstreamRequestDuration.WithLabelValues(service, operation, upstreamOutcome(err)).Observe(time.Since(startTime).Seconds())
+}
+
+func upstreamOutcome(err error) string {
+
+	if err == nil {
+		return outcomeSuccess
+	}
+
+	if errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests) {
+		return outcomeCircuitOpen
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return outcomeTimeout
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return outcomeTimeout
+	}
+
+	var statusErr *upstreamStatusError
+	if errors.As(err, &statusErr) {
+		if statusErr.statusCode >= http.StatusInternalServerError {
+			return outcomeServerError
+		}
+		return outcomeClientError
+	}
+
+	return outcomeError
+}
+
+func InitCircuitBreakerState(name string, state gobreaker.State) {
+	circuitBreakerState.WithLabelValues(name).Set(float64(state))
+}
+
+func OnCircuitBreakerStateChange(name string, from gobreaker.State, to gobreaker.State) {
+
+	circuitBreakerState.WithLabelValues(name).Set(float64(to))
+	circuitBreakerTransitions.WithLabelValues(name, from.String(), to.String()).Inc()
+
+	logs.Warning(fmt.Sprintf("Circuit Breaker '%s' durumu '%s' -> '%s' olarak değişti", name, from, to),
+		logs.WithHandlerName("CircuitBreaker"),
+	)
+}
@@ -0,0 +1,165 @@
+package client
+
+import (
+	"context"
+	"favorite_service/internal/models"
+	"favorite_service/metrics"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sony/gobreaker"
+	"github.com/stretchr/testify/assert"
+)
+
+// upstreamSampleCount, verilen label'lar için latency histogramına düşen örnek sayısını döner.
+func upstreamSampleCount(t *testing.T, service string, operation string, outcome string) uint64 {
+
+	families, err := metrics.NewRegistry(Collectors()...).Gather()
+
+	assert.Nil(t, err)
+
+	for _, family := range families {
+
+		if family.GetName() != "favorite_service_upstream_request_duration_seconds" {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+
+			if labels["service"] == service && labels["operation"] == operation && labels["outcome"] == outcome {
+				return metric.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+
+	return 0
+}
+
+func TestUpstreamMetrics(t *testing.T) {
+
+	var serverErrorHits int32
+
+	productServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		switch r.URL.Path {
+		case "/products/1":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"SuccesData":{"id":1,"name":"Kalem","price":10,"stock":3}}`))
+		case "/products/2":
+			w.WriteHeader(http.StatusNotFound)
+		case "/products/3":
+			atomic.AddInt32(&serverErrorHits, 1)
+			w.WriteHeader(http.StatusInternalServerError)
+		case "/products/4":
+			time.Sleep(100 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer productServer.Close()
+
+	productClient := NewProductClient(productServer.URL, 3, time.Millisecond)
+
+	t.Run("TestUpstreamMetricsOutcomeLabels", func(t *testing.T) {
+
+		success := upstreamSampleCount(t, "product", "GetProduct", outcomeSuccess)
+		clientError := upstreamSampleCount(t, "product", "GetProduct", outcomeClientError)
+		timeout := upstreamSampleCount(t, "product", "GetProduct", outcomeTimeout)
+
+		_, err := productClient.GetProduct(context.Background(), 1)
+
+		assert.Nil(t, err)
+
+		_, err = productClient.GetProduct(context.Background(), 2)
+
+		assert.ErrorIs(t, err, models.ErrRecordNotFound)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err = productClient.GetProduct(ctx, 4)
+
+		assert.NotNil(t, err)
+
+		assert.Equal(t, success+1, upstreamSampleCount(t, "product", "GetProduct", outcomeSuccess))
+		assert.Equal(t, clientError+1, upstreamSampleCount(t, "product", "GetProduct", outcomeClientError))
+		assert.Equal(t, timeout+1, upstreamSampleCount(t, "product", "GetProduct", outcomeTimeout))
+
+	})
+
+	t.Run("TestUpstreamMetricsRetriesOnServerError", func(t *testing.T) {
+
+		retries := testutil.ToFloat64(upstreamRetries.WithLabelValues("product", "GetProduct"))
+		serverError := upstreamSampleCount(t, "product", "GetProduct", outcomeServerError)
+
+		_, err := productClient.VerifyProduct(context.Background(), 3)
+
+		assert.ErrorIs(t, err, models.ErrProductServiceUnavailable)
+
+		assert.Equal(t, int32(3), atomic.LoadInt32(&serverErrorHits))
+		assert.Equal(t, retries+2, testutil.ToFloat64(upstreamRetries.WithLabelValues("product", "GetProduct")))
+		assert.Equal(t, serverError+3, upstreamSampleCount(t, "product", "GetProduct", outcomeServerError))
+
+	})
+
+	t.Run("TestUpstreamMetricsNoRetryOnNotFound", func(t *testing.T) {
+
+		retries := testutil.ToFloat64(upstreamRetries.WithLabelValues("product", "GetProduct"))
+
+		_, err := productClient.VerifyProduct(context.Background(), 2)
+
+		assert.ErrorIs(t, err, models.ErrRecordNotFound)
+
+		assert.Equal(t, retries, testutil.ToFloat64(upstreamRetries.WithLabelValues("product", "GetProduct")))
+
+	})
+
+	t.Run("TestUpstreamMetricsCircuitBreaker", func(t *testing.T) {
+
+		userServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer userServer.Close()
+
+		cb := gobreaker.NewCircuitBreaker(gobreaker.Settings{
+			Name:    "test-user-service",
+			Timeout: time.Minute,
+			ReadyToTrip: func(counts gobreaker.Counts) bool {
+				return counts.ConsecutiveFailures >= 2
+			},
+			OnStateChange: OnCircuitBreakerStateChange,
+		})
+
+		InitCircuitBreakerState(cb.Name(), cb.State())
+
+		assert.Equal(t, float64(gobreaker.StateClosed), testutil.ToFloat64(circuitBreakerState.WithLabelValues("test-user-service")))
+
+		userClient := NewUserClient(userServer.URL, cb)
+
+		serverError := upstreamSampleCount(t, "user", "VerifyUser", outcomeServerError)
+		circuitOpen := upstreamSampleCount(t, "user", "VerifyUser", outcomeCircuitOpen)
+
+		for i := 0; i < 3; i++ {
+
+			_, err := userClient.VerifyUser("1", context.Background())
+
+			assert.NotNil(t, err)
+		}
+
+		assert.Equal(t, serverError+2, upstreamSampleCount(t, "user", "VerifyUser", outcomeServerError))
+		assert.Equal(t, circuitOpen+1, upstreamSampleCount(t, "user", "VerifyUser", outcomeCircuitOpen))
+
+		assert.Equal(t, float64(gobreaker.StateOpen), testutil.ToFloat64(circuitBreakerState.WithLabelValues("test-user-service")))
+		assert.Equal(t, float64(1), testutil.ToFloat64(circuitBreakerTransitions.WithLabelValues("test-user-service", "closed", "open")))
+
+	})
+
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"favorite_service/internal/models"
 	"fmt"
 	"net/http"
@@ -35,6 +36,13 @@ func (c *ProductClient) VerifyProduct(ctx context.Context, productId int) (*mode
 
 		lastErr = err
 
+		// Ürün yoksa tekrar denemek sonucu değiştirmez.
+		if errors.Is(err, models.ErrRecordNotFound) || retry == c.maxRetries-1 {
+			break
+		}
+
+		upstreamRetries.WithLabelValues("product", "GetProduct").Inc()
+
 		select {
 		case <-ctx.Done():
 			return nil, ctx.Err()
@@ -44,10 +52,15 @@ func (c *ProductClient) VerifyProduct(ctx context.Context, productId int) (*mode
 
 	}
 
-	return nil, fmt.Errorf("istek sayisi (%d) , last error: %v", c.maxRetries, lastErr)
+	return nil, fmt.Errorf("istek sayisi (%d) , last error: %w", c.maxRetries, lastErr)
 }
 
-func (c *ProductClient) GetProduct(ctx context.Context, productId int) (*models.Product, error) {
+func (c *ProductClient) GetProduct(ctx context.Context, productId int) (product *models.Product, err error) {
+
+	startTime := time.Now()
+	defer func() {
+		observeUpstream("product", "GetProduct", startTime, err)
+	}()
 
 	productServiceURL := fmt.Sprintf("%s/products/%d", c.productServiceURL, productId)
 
@@ -69,9 +82,15 @@ func (c *ProductClient) GetProduct(ctx context.Context, productId int) (*models.
 	}
 	defer respProductService.Body.Close()
 
+	if respProductService.StatusCode == http.StatusNotFound {
+
+		return nil, &upstreamStatusError{statusCode: respProductService.StatusCode, err: models.ErrRecordNotFound}
+
+	}
+
 	if respProductService.StatusCode != http.StatusOK {
 
-		return nil, models.ErrRecordNotFound
+		return nil, &upstreamStatusError{statusCode: respProductService.StatusCode, err: models.ErrProductServiceUnavailable}
 
 	}
 
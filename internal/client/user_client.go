@@ -28,6 +28,8 @@ func NewUserClient(userServiceURL string, cb circuitBreaker) *UserClient {
 
 func (c *UserClient) VerifyUser(token string, ctx context.Context) (*models.Users, error) {
 
+	startTime := time.Now()
+
 	result, err := c.cb.Execute(func() (interface{}, error) {
 
 		userServiceURL := fmt.Sprintf("%s/users/me", c.userServiceURL)
@@ -60,7 +62,7 @@ func (c *UserClient) VerifyUser(token string, ctx context.Context) (*models.User
 
 		if resp.StatusCode != http.StatusOK {
 
-			return nil, models.ErrUserUnauthorized
+			return nil, &upstreamStatusError{statusCode: resp.StatusCode, err: models.ErrUserUnauthorized}
 		}
 
 		var userResponse models.UserResponse
@@ -75,6 +77,8 @@ func (c *UserClient) VerifyUser(token string, ctx context.Context) (*models.User
 
 	})
 
+	observeUpstream("user", "VerifyUser", startTime, err)
+
 	if err != nil {
 		return nil, err
 	}
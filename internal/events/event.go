@@ -0,0 +1,64 @@
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SchemaVersion event zarfının ve Data içeriklerinin sürümüdür. Alan silme veya
+// anlam değiştirme gibi geriye uyumsuz değişikliklerde artırılmalıdır.
+const SchemaVersion = 1
+
+const (
+	TypeFavoriteItemAdded   = "favorite.item.added"
+	TypeFavoriteItemRemoved = "favorite.item.removed"
+	TypeFavoriteListCreated = "favorite.list.created"
+	TypeFavoriteListUpdated = "favorite.list.updated"
+	TypeFavoriteListDeleted = "favorite.list.deleted"
+)
+
+var Types = []string{
+	TypeFavoriteItemAdded,
+	TypeFavoriteItemRemoved,
+	TypeFavoriteListCreated,
+	TypeFavoriteListUpdated,
+	TypeFavoriteListDeleted,
+}
+
+type Event struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	Version    int             `json:"version"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+type FavoriteItemEventData struct {
+	UserId int `json:"user_id"`
+	ListId int `json:"list_id"`
+	ItemId int `json:"item_id"`
+}
+
+type FavoriteListEventData struct {
+	UserId   int    `json:"user_id"`
+	ListId   int    `json:"list_id"`
+	ListName string `json:"list_name,omitempty"`
+}
+
+func NewEvent(eventType string, data interface{}) (Event, error) {
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, err
+	}
+
+	return Event{
+		ID:         uuid.NewString(),
+		Type:       eventType,
+		Version:    SchemaVersion,
+		OccurredAt: time.Now().UTC(),
+		Data:       raw,
+	}, nil
+}
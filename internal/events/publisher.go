@@ -0,0 +1,124 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"favorite_service/logs"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+type LogPublisher struct{}
+
+func NewLogPublisher() *LogPublisher {
+	return &LogPublisher{}
+}
+
+func (p *LogPublisher) Publish(ctx context.Context, event Event) error {
+
+	logs.Info(fmt.Sprintf("Event yayınlandı: %s %s", event.Type, event.ID),
+		logs.WithHandlerName("LogPublisher"),
+	)
+
+	return nil
+}
+
+type HTTPPublisher struct {
+	url    string
+	client *http.Client
+}
+
+func NewHTTPPublisher(url string, timeout time.Duration) *HTTPPublisher {
+	return &HTTPPublisher{
+		url: url,
+		client: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+func (p *HTTPPublisher) Publish(ctx context.Context, event Event) error {
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-Id", event.ID)
+	req.Header.Set("X-Event-Type", event.Type)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %d status döndü", resp.StatusCode)
+	}
+
+	return nil
+}
+
+type MemoryPublisher struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+func (p *MemoryPublisher) Publish(ctx context.Context, event Event) error {
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.events = append(p.events, event)
+
+	return nil
+}
+
+func (p *MemoryPublisher) Events() []Event {
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]Event(nil), p.events...)
+}
+
+// MultiPublisher eventi sırayla tüm publisher'lara iletir. Biri hata verirse event
+// tekrar denenir, bu yüzden publisher'ların tekrar eden eventlere dayanıklı olması gerekir.
+type MultiPublisher struct {
+	publishers []Publisher
+}
+
+func NewMultiPublisher(publishers ...Publisher) *MultiPublisher {
+	return &MultiPublisher{
+		publishers: publishers,
+	}
+}
+
+func (p *MultiPublisher) Publish(ctx context.Context, event Event) error {
+
+	for _, publisher := range p.publishers {
+		if err := publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package events
+
+import (
+	"context"
+	"favorite_service/logs"
+	"fmt"
+	"time"
+)
+
+// PendingEvent yayınlanmak üzere sahiplenilmiş bir event ve şimdiye kadarki deneme sayısıdır.
+type PendingEvent struct {
+	Event
+	Attempts int
+}
+
+type outboxStore interface {
+	ClaimPendingEvents(ctx context.Context, limit int, leaseUntil time.Time) ([]PendingEvent, error)
+	MarkPublished(ctx context.Context, eventId string) error
+	MarkFailed(ctx context.Context, eventId string, publishErr error, nextAttemptDate time.Time, deadLetter bool) error
+}
+
+// claimLease sahiplenilen eventlerin diğer relay'lerden gizlendiği süredir. Relay bu süre
+// içinde sonucu yazamazsa (ör. süreç çökerse) eventler tekrar sahiplenilir.
+const claimLease = 5 * time.Minute
+
+// Relay outbox tablosundaki eventleri Publisher'a iletir. Bir event ancak başarıyla
+// yayınlandıktan sonra işaretlendiği için teslimat en az bir kez (at-least-once) garantilidir;
+// tüketiciler Event.ID ile tekrarları ayıklamalıdır.
+type Relay struct {
+	store       outboxStore
+	publisher   Publisher
+	batchSize   int
+	maxAttempts int
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+}
+
+func NewRelay(store outboxStore, publisher Publisher, batchSize int, maxAttempts int, baseBackoff time.Duration, maxBackoff time.Duration) *Relay {
+	return &Relay{
+		store:       store,
+		publisher:   publisher,
+		batchSize:   batchSize,
+		maxAttempts: maxAttempts,
+		baseBackoff: baseBackoff,
+		maxBackoff:  maxBackoff,
+	}
+}
+
+func (r *Relay) Run(ctx context.Context, interval time.Duration) {
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			published, err := r.RelayOnce(ctx)
+			if err != nil {
+				logs.Error(err.Error(), logs.WithHandlerName("OutboxRelay"))
+				break
+			}
+			if published < r.batchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayOnce bir batch event'i sahiplenir ve başarılı yayınlanan event sayısını döner.
+// Sahiplenme kendi kısa transaction'ı içinde commit edilir; yayınlama transaction dışında
+// yapıldığı için yavaş bir publisher satır kilidi ya da veritabanı bağlantısı tutmaz.
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+
+	pending, err := r.store.ClaimPendingEvents(ctx, r.batchSize, time.Now().Add(claimLease))
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+
+	for _, event := range pending {
+
+		if err := r.publisher.Publish(ctx, event.Event); err != nil {
+
+			attempts := event.Attempts + 1
+			deadLetter := attempts >= r.maxAttempts
+
+			if deadLetter {
+				logs.Error(fmt.Sprintf("Event %s %d denemeden sonra dead-letter durumuna alındı: %v", event.ID, attempts, err),
+					logs.WithHandlerName("OutboxRelay"),
+				)
+			} else {
+				logs.Warning(fmt.Sprintf("Event %s yayınlanamadı: %v", event.ID, err),
+					logs.WithHandlerName("OutboxRelay"),
+				)
+			}
+
+			if err := r.store.MarkFailed(ctx, event.ID, err, time.Now().Add(r.Backoff(attempts)), deadLetter); err != nil {
+				return published, err
+			}
+			continue
+		}
+
+		if err := r.store.MarkPublished(ctx, event.ID); err != nil {
+			return published, err
+		}
+
+		published++
+	}
+
+	return published, nil
+}
+
+// Backoff n. başarısız denemeden sonra beklenecek süreyi üstel olarak hesaplar ve maxBackoff ile sınırlar.
+func (r *Relay) Backoff(attempts int) time.Duration {
+
+	backoff := r.baseBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= r.maxBackoff {
+			return r.maxBackoff
+		}
+	}
+
+	return backoff
+}
@@ -0,0 +1,166 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeOutboxStore struct {
+	pending    []Event
+	published  map[string]bool
+	failures   map[string]int
+	deadLetter map[string]bool
+	retryAt    map[string]time.Time
+}
+
+func (s *fakeOutboxStore) ClaimPendingEvents(ctx context.Context, limit int, leaseUntil time.Time) ([]PendingEvent, error) {
+	var events []PendingEvent
+	for _, event := range s.pending {
+		if s.published[event.ID] || s.deadLetter[event.ID] || s.retryAt[event.ID].After(time.Now()) || len(events) >= limit {
+			continue
+		}
+		events = append(events, PendingEvent{Event: event, Attempts: s.failures[event.ID]})
+	}
+	return events, nil
+}
+
+func (s *fakeOutboxStore) MarkPublished(ctx context.Context, eventId string) error {
+	s.published[eventId] = true
+	return nil
+}
+
+func (s *fakeOutboxStore) MarkFailed(ctx context.Context, eventId string, publishErr error, nextAttemptDate time.Time, deadLetter bool) error {
+	s.failures[eventId]++
+	s.retryAt[eventId] = nextAttemptDate
+	s.deadLetter[eventId] = deadLetter
+	return nil
+}
+
+type flakyPublisher struct {
+	*MemoryPublisher
+	failNext bool
+	failAll  bool
+}
+
+func (p *flakyPublisher) Publish(ctx context.Context, event Event) error {
+	if p.failNext || p.failAll {
+		p.failNext = false
+		return errors.New("publisher hatası")
+	}
+	return p.MemoryPublisher.Publish(ctx, event)
+}
+
+func newFakeOutboxStore(pending ...Event) *fakeOutboxStore {
+	return &fakeOutboxStore{
+		pending:    pending,
+		published:  map[string]bool{},
+		failures:   map[string]int{},
+		deadLetter: map[string]bool{},
+		retryAt:    map[string]time.Time{},
+	}
+}
+
+func TestRelay(t *testing.T) {
+
+	first, err := NewEvent(TypeFavoriteItemAdded, FavoriteItemEventData{UserId: 1, ListId: 1, ItemId: 10})
+	assert.Nil(t, err)
+
+	second, err := NewEvent(TypeFavoriteListDeleted, FavoriteListEventData{UserId: 1, ListId: 2})
+	assert.Nil(t, err)
+
+	store := newFakeOutboxStore(first, second)
+
+	publisher := &flakyPublisher{MemoryPublisher: NewMemoryPublisher(), failNext: true}
+
+	relay := NewRelay(store, publisher, 10, 3, 0, 0)
+
+	t.Run("TestRelayOnceRetriesFailedEvents", func(t *testing.T) {
+
+		published, err := relay.RelayOnce(context.Background())
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, 1, published)
+
+		assert.Equal(t, 1, store.failures[first.ID])
+
+		published, err = relay.RelayOnce(context.Background())
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, 1, published)
+
+		assert.True(t, store.published[first.ID])
+
+		assert.True(t, store.published[second.ID])
+
+	})
+
+	t.Run("TestRelayOnceDeadLettersAfterMaxAttempts", func(t *testing.T) {
+
+		failing, err := NewEvent(TypeFavoriteItemAdded, FavoriteItemEventData{UserId: 2, ListId: 3, ItemId: 4})
+		assert.Nil(t, err)
+
+		failingStore := newFakeOutboxStore(failing)
+
+		failingRelay := NewRelay(failingStore, &flakyPublisher{MemoryPublisher: NewMemoryPublisher(), failAll: true}, 10, 3, 0, 0)
+
+		for i := 0; i < 5; i++ {
+			_, err := failingRelay.RelayOnce(context.Background())
+			assert.Nil(t, err)
+		}
+
+		assert.Equal(t, 3, failingStore.failures[failing.ID])
+
+		assert.True(t, failingStore.deadLetter[failing.ID])
+
+	})
+
+	t.Run("TestRelayOnceBacksOffFailedEvents", func(t *testing.T) {
+
+		failing, err := NewEvent(TypeFavoriteItemAdded, FavoriteItemEventData{UserId: 2, ListId: 3, ItemId: 5})
+		assert.Nil(t, err)
+
+		failingStore := newFakeOutboxStore(failing)
+
+		failingRelay := NewRelay(failingStore, &flakyPublisher{MemoryPublisher: NewMemoryPublisher(), failAll: true}, 10, 3, time.Hour, time.Hour)
+
+		_, err = failingRelay.RelayOnce(context.Background())
+		assert.Nil(t, err)
+
+		_, err = failingRelay.RelayOnce(context.Background())
+		assert.Nil(t, err)
+
+		assert.Equal(t, 1, failingStore.failures[failing.ID])
+
+	})
+
+	t.Run("TestBackoff", func(t *testing.T) {
+
+		backoffRelay := NewRelay(newFakeOutboxStore(), NewMemoryPublisher(), 10, 10, time.Second, 10*time.Second)
+
+		assert.Equal(t, time.Second, backoffRelay.Backoff(1))
+		assert.Equal(t, 4*time.Second, backoffRelay.Backoff(3))
+		assert.Equal(t, 10*time.Second, backoffRelay.Backoff(8))
+
+	})
+
+	t.Run("TestEventSchema", func(t *testing.T) {
+
+		for _, event := range publisher.Events() {
+
+			assert.Equal(t, SchemaVersion, event.Version)
+
+			assert.NotEmpty(t, event.ID)
+
+			assert.NotEmpty(t, event.Data)
+
+		}
+
+	})
+
+}
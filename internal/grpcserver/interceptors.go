@@ -0,0 +1,92 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	favoritev1 "favorite_service/api/favorite/v1"
+	"favorite_service/internal/models"
+	"favorite_service/logs"
+	"favorite_service/metrics"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationMetadataKey = "authorization"
+	maxContainsProductIds    = 100
+)
+
+func NewGRPCServer(server *Server, grpcMetrics *metrics.GRPCMetrics) *grpc.Server {
+
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			grpcMetrics.UnaryServerInterceptor(),
+			LoggingUnaryInterceptor(),
+		),
+	)
+
+	favoritev1.RegisterFavoriteServiceServer(grpcServer, server)
+
+	return grpcServer
+}
+
+func LoggingUnaryInterceptor() grpc.UnaryServerInterceptor {
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err)
+
+		switch {
+		case err == nil:
+			logs.Info("gRPC isteği başarılı", logs.WithHandlerName(info.FullMethod))
+		case code == codes.Internal || code == codes.Unknown:
+			logs.Error(err.Error(), logs.WithHandlerName(info.FullMethod))
+		default:
+			logs.Warning(err.Error(), logs.WithHandlerName(info.FullMethod))
+		}
+
+		return resp, err
+	}
+}
+
+func tokenFromContext(ctx context.Context) (string, error) {
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "Token Authorization Hatasi")
+	}
+
+	values := md.Get(authorizationMetadataKey)
+	if len(values) == 0 || values[0] == "" {
+		return "", status.Error(codes.Unauthenticated, "Token Authorization Hatasi")
+	}
+
+	return values[0], nil
+}
+
+func toStatus(err error) error {
+
+	var quotaErr *models.QuotaExceededError
+
+	switch {
+	case errors.As(err, &quotaErr):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, models.ErrUserUnauthorized), errors.Is(err, models.ErrUserNotFound):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, models.ErrunaUthorizedAction):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, models.ErrRecordNotFound), errors.Is(err, models.ErrProductNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
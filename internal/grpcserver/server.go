@@ -0,0 +1,239 @@
+package grpcserver
+
+import (
+	"context"
+	favoritev1 "favorite_service/api/favorite/v1"
+	"favorite_service/internal/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type favoriteListService interface {
+	GetUserFavoriteListsWithItems(token string, ctx context.Context) ([]models.FavoriteListResponse, error)
+	CreateFavoriteList(list *models.FavoriteList, token string, ctx context.Context) error
+	UpdateFavoriteList(listId int, list models.UpdateFavoriteList, expectedVersion *int, token string, ctx context.Context) (models.FavoriteList, error)
+	DeleteFavoriteList(listId int, expectedVersion *int, token string, ctx context.Context) error
+}
+
+type favoriteItemService interface {
+	GetFavoriteItem(listId int, token string, ctx context.Context) ([]models.FavoriteProduct, error)
+	CreateFavoriteItem(item models.CreateFavoriteItem, token string, ctx context.Context) (models.FavoriteItem, error)
+	DeleteFavoriteItem(listId int, itemId int, token string, ctx context.Context) error
+	ContainsFavoriteItems(productIds []int, token string, ctx context.Context) ([]models.FavoriteContainsResponse, error)
+}
+
+// Server REST handler'larının kullandığı servis katmanını gRPC üzerinden sunar.
+type Server struct {
+	favoritev1.UnimplementedFavoriteServiceServer
+	listService favoriteListService
+	itemService favoriteItemService
+}
+
+func NewServer(listService favoriteListService, itemService favoriteItemService) *Server {
+	return &Server{
+		listService: listService,
+		itemService: itemService,
+	}
+}
+
+func (s *Server) ListFavoriteLists(ctx context.Context, req *favoritev1.ListFavoriteListsRequest) (*favoritev1.ListFavoriteListsResponse, error) {
+
+	token, err := tokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lists, err := s.listService.GetUserFavoriteListsWithItems(token, ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	response := &favoritev1.ListFavoriteListsResponse{}
+	for _, list := range lists {
+		response.Lists = append(response.Lists, &favoritev1.FavoriteListWithProducts{
+			ListId:   int64(list.ListId),
+			ListName: list.ListName,
+			Products: toProtoProducts(list.Items),
+		})
+	}
+
+	return response, nil
+}
+
+func (s *Server) CreateFavoriteList(ctx context.Context, req *favoritev1.CreateFavoriteListRequest) (*favoritev1.FavoriteList, error) {
+
+	token, err := tokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	request := models.CreateFavoriteList{ListName: req.GetListName()}
+	if err := request.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	list := models.FavoriteList{ListName: request.ListName}
+
+	if err := s.listService.CreateFavoriteList(&list, token, ctx); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toProtoList(list), nil
+}
+
+func (s *Server) UpdateFavoriteList(ctx context.Context, req *favoritev1.UpdateFavoriteListRequest) (*favoritev1.FavoriteList, error) {
+
+	token, err := tokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	request := models.UpdateFavoriteList{ListName: req.GetListName()}
+	if err := request.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	list, err := s.listService.UpdateFavoriteList(int(req.GetListId()), request, nil, token, ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toProtoList(list), nil
+}
+
+func (s *Server) DeleteFavoriteList(ctx context.Context, req *favoritev1.DeleteFavoriteListRequest) (*favoritev1.DeleteFavoriteListResponse, error) {
+
+	token, err := tokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.listService.DeleteFavoriteList(int(req.GetListId()), nil, token, ctx); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &favoritev1.DeleteFavoriteListResponse{}, nil
+}
+
+func (s *Server) GetFavoriteItems(ctx context.Context, req *favoritev1.GetFavoriteItemsRequest) (*favoritev1.GetFavoriteItemsResponse, error) {
+
+	token, err := tokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := s.itemService.GetFavoriteItem(int(req.GetListId()), token, ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &favoritev1.GetFavoriteItemsResponse{Products: toProtoProducts(products)}, nil
+}
+
+func (s *Server) AddFavoriteItem(ctx context.Context, req *favoritev1.AddFavoriteItemRequest) (*favoritev1.FavoriteItem, error) {
+
+	token, err := tokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	request := models.CreateFavoriteItem{ItemId: int(req.GetItemId()), ListId: int(req.GetListId())}
+	if err := request.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	item, err := s.itemService.CreateFavoriteItem(request, token, ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &favoritev1.FavoriteItem{
+		ItemId:      int64(item.ItemId),
+		ListId:      int64(item.ListId),
+		CreatedDate: timestamppb.New(item.CreatedDate),
+	}, nil
+}
+
+func (s *Server) RemoveFavoriteItem(ctx context.Context, req *favoritev1.RemoveFavoriteItemRequest) (*favoritev1.RemoveFavoriteItemResponse, error) {
+
+	token, err := tokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.itemService.DeleteFavoriteItem(int(req.GetListId()), int(req.GetItemId()), token, ctx); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &favoritev1.RemoveFavoriteItemResponse{}, nil
+}
+
+func (s *Server) ContainsFavoriteItems(ctx context.Context, req *favoritev1.ContainsFavoriteItemsRequest) (*favoritev1.ContainsFavoriteItemsResponse, error) {
+
+	token, err := tokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.GetProductIds()) == 0 || len(req.GetProductIds()) > maxContainsProductIds {
+		return nil, status.Errorf(codes.InvalidArgument, "1-%d arası ürün id gönderilmeli", maxContainsProductIds)
+	}
+
+	productIds := make([]int, 0, len(req.GetProductIds()))
+	seen := make(map[int]struct{}, len(req.GetProductIds()))
+	for _, productId := range req.GetProductIds() {
+		if _, ok := seen[int(productId)]; ok {
+			continue
+		}
+		seen[int(productId)] = struct{}{}
+		productIds = append(productIds, int(productId))
+	}
+
+	contains, err := s.itemService.ContainsFavoriteItems(productIds, token, ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	response := &favoritev1.ContainsFavoriteItemsResponse{}
+	for _, product := range contains {
+		productStatus := &favoritev1.ProductFavoriteStatus{
+			ProductId: int64(product.ProductId),
+			Favorited: product.Favorited,
+		}
+		for _, list := range product.Lists {
+			productStatus.Lists = append(productStatus.Lists, &favoritev1.FavoriteListRef{
+				ListId:   int64(list.ListId),
+				ListName: list.ListName,
+			})
+		}
+		response.Products = append(response.Products, productStatus)
+	}
+
+	return response, nil
+}
+
+func toProtoList(list models.FavoriteList) *favoritev1.FavoriteList {
+	return &favoritev1.FavoriteList{
+		ListId:      int64(list.Id),
+		ListName:    list.ListName,
+		UserId:      int64(list.UserId),
+		CreatedDate: timestamppb.New(list.CreatedDate),
+	}
+}
+
+func toProtoProducts(products []models.FavoriteProduct) []*favoritev1.Product {
+
+	protoProducts := make([]*favoritev1.Product, 0, len(products))
+	for _, product := range products {
+		protoProducts = append(protoProducts, &favoritev1.Product{
+			Id:    int64(product.ID),
+			Name:  product.Name,
+			Price: product.Price,
+			Stock: int64(product.Stock),
+		})
+	}
+
+	return protoProducts
+}
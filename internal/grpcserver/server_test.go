@@ -0,0 +1,140 @@
+package grpcserver
+
+import (
+	"context"
+	favoritev1 "favorite_service/api/favorite/v1"
+	"favorite_service/internal/models"
+	"favorite_service/metrics"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeListService struct{}
+
+func (f *fakeListService) GetUserFavoriteListsWithItems(token string, ctx context.Context) ([]models.FavoriteListResponse, error) {
+	if token != "1" {
+		return nil, models.ErrUserUnauthorized
+	}
+	return []models.FavoriteListResponse{{ListId: 1, ListName: "Alışveriş", Items: []models.FavoriteProduct{{Product: models.Product{ID: 1, Name: "Telefon"}}}}}, nil
+}
+
+func (f *fakeListService) CreateFavoriteList(list *models.FavoriteList, token string, ctx context.Context) error {
+	list.Id = 10
+	list.UserId = 1
+	return nil
+}
+
+func (f *fakeListService) UpdateFavoriteList(listId int, list models.UpdateFavoriteList, expectedVersion *int, token string, ctx context.Context) (models.FavoriteList, error) {
+	return models.FavoriteList{}, models.ErrunaUthorizedAction
+}
+
+func (f *fakeListService) DeleteFavoriteList(listId int, expectedVersion *int, token string, ctx context.Context) error {
+	return models.ErrRecordNotFound
+}
+
+type fakeItemService struct{}
+
+func (f *fakeItemService) GetFavoriteItem(listId int, token string, ctx context.Context) ([]models.FavoriteProduct, error) {
+	return []models.FavoriteProduct{{Product: models.Product{ID: 1}}}, nil
+}
+
+func (f *fakeItemService) CreateFavoriteItem(item models.CreateFavoriteItem, token string, ctx context.Context) (models.FavoriteItem, error) {
+	return models.FavoriteItem{ItemId: item.ItemId, ListId: item.ListId}, nil
+}
+
+func (f *fakeItemService) DeleteFavoriteItem(listId int, itemId int, token string, ctx context.Context) error {
+	return nil
+}
+
+func (f *fakeItemService) ContainsFavoriteItems(productIds []int, token string, ctx context.Context) ([]models.FavoriteContainsResponse, error) {
+	response := []models.FavoriteContainsResponse{}
+	for _, productId := range productIds {
+		response = append(response, models.FavoriteContainsResponse{ProductId: productId})
+	}
+	return response, nil
+}
+
+func TestServer(t *testing.T) {
+
+	listener := bufconn.Listen(1024 * 1024)
+
+	grpcServer := NewGRPCServer(NewServer(&fakeListService{}, &fakeItemService{}), metrics.NewGRPCMetrics("test", nil))
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	client := favoritev1.NewFavoriteServiceClient(conn)
+
+	authCtx := metadata.AppendToOutgoingContext(context.Background(), authorizationMetadataKey, "1")
+
+	t.Run("TestListFavoriteLists", func(t *testing.T) {
+
+		response, err := client.ListFavoriteLists(authCtx, &favoritev1.ListFavoriteListsRequest{})
+
+		assert.Nil(t, err)
+
+		assert.Len(t, response.GetLists(), 1)
+
+		assert.Equal(t, "Telefon", response.GetLists()[0].GetProducts()[0].GetName())
+
+	})
+
+	t.Run("TestMissingToken", func(t *testing.T) {
+
+		_, err := client.ListFavoriteLists(context.Background(), &favoritev1.ListFavoriteListsRequest{})
+
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	})
+
+	t.Run("TestCreateFavoriteListValidation", func(t *testing.T) {
+
+		_, err := client.CreateFavoriteList(authCtx, &favoritev1.CreateFavoriteListRequest{ListName: ""})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		list, err := client.CreateFavoriteList(authCtx, &favoritev1.CreateFavoriteListRequest{ListName: "Deneme"})
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, int64(10), list.GetListId())
+
+	})
+
+	t.Run("TestErrorMapping", func(t *testing.T) {
+
+		_, err := client.UpdateFavoriteList(authCtx, &favoritev1.UpdateFavoriteListRequest{ListId: 1, ListName: "Deneme"})
+
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		_, err = client.DeleteFavoriteList(authCtx, &favoritev1.DeleteFavoriteListRequest{ListId: 100})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+	})
+
+	t.Run("TestContainsFavoriteItems", func(t *testing.T) {
+
+		response, err := client.ContainsFavoriteItems(authCtx, &favoritev1.ContainsFavoriteItemsRequest{ProductIds: []int64{1, 2, 2}})
+
+		assert.Nil(t, err)
+
+		assert.Len(t, response.GetProducts(), 2)
+
+	})
+
+}
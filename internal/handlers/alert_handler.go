@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"context"
+	"favorite_service/internal/models"
+	"favorite_service/logs"
+	"fmt"
+
+	"github.com/go-swagno/swagno/components/endpoint"
+	"github.com/go-swagno/swagno/components/http/response"
+	"github.com/go-swagno/swagno/components/parameter"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	defaultAlertLimit = 50
+	maxAlertLimit     = 200
+)
+
+type alertService interface {
+	GetUserAlerts(token string, ctx context.Context, limit int) ([]models.ProductAlert, error)
+}
+
+type AlertHandler struct {
+	alertService alertService
+}
+
+func NewAlertHandler(alertService alertService) *AlertHandler {
+	return &AlertHandler{
+		alertService: alertService,
+	}
+}
+
+func (h *AlertHandler) GetUserAlertsHandle(c *fiber.Ctx) error {
+
+	authHeader := c.Get("Authorization")
+	if authHeader == "" {
+
+		logs.Warning("Token Authorization Hatasi",
+			logs.WithHandlerName("AlertHandler_GetUserAlerts"),
+			logs.WithStatus(fiber.StatusUnauthorized),
+		)
+
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErorResponse{
+			Error:   "Token Authorization Hatasi",
+			Details: "Token"},
+		)
+	}
+
+	limit := c.QueryInt("limit", defaultAlertLimit)
+
+	if limit < 1 || limit > maxAlertLimit {
+
+		logs.Warning("Limit aralık dışında",
+			logs.WithHandlerName("AlertHandler_GetUserAlerts"),
+			logs.WithStatus(fiber.StatusBadRequest),
+		)
+
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Limit Parametre Hatasi",
+			Details: fmt.Sprintf("limit 1-%d aralığında olmalı", maxAlertLimit)},
+		)
+	}
+
+	ctx := c.UserContext()
+
+	alerts, err := h.alertService.GetUserAlerts(authHeader, ctx, limit)
+
+	if err != nil {
+
+		logs.Error(err.Error(),
+			logs.WithHandlerName("AlertHandler_GetUserAlerts"),
+			logs.WithStatus(fiber.StatusInternalServerError),
+		)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErorResponse{
+			Error:   "Servis Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(models.SuccesResponse{SuccesData: alerts})
+
+}
+
+func (h *AlertHandler) SetRoutes(app *fiber.App) {
+
+	alertGroup := app.Group("/alerts")
+
+	alertGroup.Get("/", h.GetUserAlertsHandle)
+
+}
+
+func AlertGetEndpoints() []*endpoint.EndPoint {
+	return []*endpoint.EndPoint{
+		endpoint.New(
+			endpoint.GET,
+			"/alerts",
+			endpoint.WithTags("alerts"),
+			endpoint.WithParams(parameter.IntParam("limit", parameter.Query, parameter.WithDefault(defaultAlertLimit))),
+			endpoint.WithParams(parameter.StrParam("Authorization", parameter.Header, parameter.WithRequired())),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New([]models.ProductAlert{}, "200", "OK")}),
+			endpoint.WithErrors([]response.Response{response.New(models.ErorResponse{}, "400", "Bad Request")}),
+		),
+	}
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlertHandler(t *testing.T) {
+
+	t.Run("TestGetUserAlertsHandle", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/alerts", nil)
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+	})
+
+	t.Run("TestGetUserAlertsHandleUnauthorized", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/alerts", nil)
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusUnauthorized, response.StatusCode)
+
+	})
+
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"context"
+	"favorite_service/internal/models"
+	"favorite_service/logs"
+	"fmt"
+	"time"
+
+	"github.com/go-swagno/swagno/components/endpoint"
+	"github.com/go-swagno/swagno/components/http/response"
+	"github.com/go-swagno/swagno/components/parameter"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	defaultAuditLimit = 50
+	maxAuditLimit     = 500
+)
+
+type auditService interface {
+	GetAuditLog(ctx context.Context, filter models.AuditLogFilter) (models.AuditLogPage, error)
+}
+
+type AuditHandler struct {
+	auditService auditService
+	adminToken   string
+}
+
+func NewAuditHandler(auditService auditService, adminToken string) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+		adminToken:   adminToken,
+	}
+}
+
+func (h *AuditHandler) GetAuditLogHandle(c *fiber.Ctx) error {
+
+	limit := c.QueryInt("limit", defaultAuditLimit)
+
+	if limit < 1 || limit > maxAuditLimit {
+
+		logs.Warning("Limit aralık dışında",
+			logs.WithHandlerName("AuditHandler_GetAuditLog"),
+			logs.WithStatus(fiber.StatusBadRequest),
+		)
+
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Limit Parametre Hatasi",
+			Details: fmt.Sprintf("limit 1-%d aralığında olmalı", maxAuditLimit)},
+		)
+	}
+
+	filter := models.AuditLogFilter{
+		ListId: c.QueryInt("listId"),
+		UserId: c.QueryInt("userId"),
+		Cursor: int64(c.QueryInt("cursor")),
+		Limit:  limit,
+	}
+
+	var err error
+
+	if filter.From, err = parseTimeQuery(c, "from"); err == nil {
+		filter.To, err = parseTimeQuery(c, "to")
+	}
+
+	if err == nil {
+		err = filter.Validate()
+	}
+
+	if err != nil {
+
+		logs.Warning(err.Error(),
+			logs.WithHandlerName("AuditHandler_GetAuditLog"),
+			logs.WithStatus(fiber.StatusBadRequest),
+		)
+
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Query Parametre Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	ctx := c.UserContext()
+
+	page, err := h.auditService.GetAuditLog(ctx, filter)
+
+	if err != nil {
+
+		logs.Error(err.Error(),
+			logs.WithHandlerName("AuditHandler_GetAuditLog"),
+			logs.WithStatus(fiber.StatusInternalServerError),
+		)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErorResponse{
+			Error:   "Servis Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	logs.Info("Audit Kayıtları Getirildi",
+		logs.WithHandlerName("AuditHandler_GetAuditLog"),
+		logs.WithStatus(fiber.StatusOK),
+	)
+
+	return c.Status(fiber.StatusOK).JSON(models.SuccesResponse{SuccesData: page})
+}
+
+// parseTimeQuery RFC 3339 biçimindeki zaman parametresini okur; parametre yoksa nil döner.
+func parseTimeQuery(c *fiber.Ctx, name string) (*time.Time, error) {
+
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("%s RFC 3339 biçiminde olmalı: %w", name, err)
+	}
+
+	parsed = parsed.UTC()
+
+	return &parsed, nil
+}
+
+func (h *AuditHandler) SetRoutes(app *fiber.App) {
+
+	auditGroup := app.Group("/admin/audit", RequireAdminToken(h.adminToken))
+
+	auditGroup.Get("/", h.GetAuditLogHandle)
+
+}
+
+func AuditGetEndpoints() []*endpoint.EndPoint {
+	return []*endpoint.EndPoint{
+		endpoint.New(
+			endpoint.GET,
+			"/admin/audit",
+			endpoint.WithTags("audit"),
+			endpoint.WithParams(parameter.IntParam("listId", parameter.Query, parameter.WithDescription("listId veya userId zorunlu"))),
+			endpoint.WithParams(parameter.IntParam("userId", parameter.Query)),
+			endpoint.WithParams(parameter.StrParam("from", parameter.Query, parameter.WithDescription("RFC 3339, dahil"))),
+			endpoint.WithParams(parameter.StrParam("to", parameter.Query, parameter.WithDescription("RFC 3339, hariç"))),
+			endpoint.WithParams(parameter.IntParam("cursor", parameter.Query, parameter.WithDescription("Önceki sayfanın next_cursor değeri"))),
+			endpoint.WithParams(parameter.IntParam("limit", parameter.Query, parameter.WithDefault(defaultAuditLimit))),
+			endpoint.WithParams(parameter.StrParam(adminTokenHeader, parameter.Header, parameter.WithRequired())),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New(models.AuditLogPage{}, "200", "OK")}),
+			endpoint.WithErrors([]response.Response{response.New(models.ErorResponse{}, "400", "Bad Request")}),
+		),
+	}
+}
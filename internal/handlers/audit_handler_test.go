@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"favorite_service/internal/models"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditHandler(t *testing.T) {
+
+	var listId int
+
+	t.Run("TestAuditHandlerRecordsMutations", func(t *testing.T) {
+
+		body, err := json.Marshal(models.CreateFavoriteList{ListName: "Audit Liste"})
+
+		assert.Nil(t, err)
+
+		request := httptest.NewRequest("POST", "/lists", bytes.NewReader(body))
+
+		request.Header.Set("Content-Type", "application/json")
+
+		request.Header.Set("Authorization", "1")
+
+		request.Header.Set(fiber.HeaderXRequestID, "audit-test-1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+		assert.Equal(t, "audit-test-1", response.Header.Get(fiber.HeaderXRequestID))
+
+		var created struct {
+			SuccesData models.FavoriteList
+		}
+
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&created))
+
+		listId = created.SuccesData.Id
+
+		request = httptest.NewRequest("PATCH", fmt.Sprintf("/lists/%d", listId), bytes.NewReader([]byte(`{"description":"Audit açıklama"}`)))
+
+		request.Header.Set("Content-Type", "application/json")
+
+		request.Header.Set("Authorization", "1")
+
+		response, err = app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+	})
+
+	t.Run("TestGetAuditLogHandleByList", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", fmt.Sprintf("/admin/audit?listId=%d&limit=1", listId), nil)
+
+		request.Header.Set(adminTokenHeader, testAdminToken)
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+		var body struct {
+			SuccesData models.AuditLogPage
+		}
+
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&body))
+
+		assert.Len(t, body.SuccesData.Entries, 1)
+
+		assert.Equal(t, models.AuditActionUpdate, body.SuccesData.Entries[0].Action)
+
+		assert.NotEmpty(t, body.SuccesData.Entries[0].Before)
+
+		assert.NotEmpty(t, body.SuccesData.Entries[0].After)
+
+		assert.NotNil(t, body.SuccesData.NextCursor)
+
+		request = httptest.NewRequest("GET", fmt.Sprintf("/admin/audit?listId=%d&cursor=%d", listId, *body.SuccesData.NextCursor), nil)
+
+		request.Header.Set(adminTokenHeader, testAdminToken)
+
+		response, err = app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&body))
+
+		assert.Len(t, body.SuccesData.Entries, 1)
+
+		assert.Equal(t, models.AuditActionCreate, body.SuccesData.Entries[0].Action)
+
+		assert.Equal(t, "audit-test-1", body.SuccesData.Entries[0].RequestId)
+
+		assert.Nil(t, body.SuccesData.NextCursor)
+
+	})
+
+	t.Run("TestSetDefaultListAuditsDemotedList", func(t *testing.T) {
+
+		setDefault := func(id int) {
+
+			request := httptest.NewRequest("PUT", fmt.Sprintf("/lists/%d/default", id), nil)
+
+			request.Header.Set("Authorization", "1")
+
+			response, err := app.Test(request)
+
+			assert.Nil(t, err)
+
+			assert.Equal(t, fiber.StatusOK, response.StatusCode)
+		}
+
+		body, err := json.Marshal(models.CreateFavoriteList{ListName: "Audit Yeni Varsayilan"})
+
+		assert.Nil(t, err)
+
+		request := httptest.NewRequest("POST", "/lists", bytes.NewReader(body))
+
+		request.Header.Set("Content-Type", "application/json")
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		var created struct {
+			SuccesData models.FavoriteList
+		}
+
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&created))
+
+		setDefault(listId)
+
+		setDefault(created.SuccesData.Id)
+
+		request = httptest.NewRequest("GET", fmt.Sprintf("/admin/audit?listId=%d&limit=1", listId), nil)
+
+		request.Header.Set(adminTokenHeader, testAdminToken)
+
+		response, err = app.Test(request)
+
+		assert.Nil(t, err)
+
+		var page struct {
+			SuccesData models.AuditLogPage
+		}
+
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&page))
+
+		assert.Len(t, page.SuccesData.Entries, 1)
+
+		assert.Equal(t, models.AuditActionUpdate, page.SuccesData.Entries[0].Action)
+
+		var demoted models.FavoriteList
+
+		assert.Nil(t, json.Unmarshal(page.SuccesData.Entries[0].After, &demoted))
+
+		assert.False(t, demoted.IsDefault)
+
+	})
+
+	t.Run("TestGetAuditLogHandleTimeRange", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/admin/audit?userId=1&to=2000-01-01T00:00:00Z", nil)
+
+		request.Header.Set(adminTokenHeader, testAdminToken)
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+		var body struct {
+			SuccesData models.AuditLogPage
+		}
+
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&body))
+
+		assert.Empty(t, body.SuccesData.Entries)
+
+	})
+
+	t.Run("TestGetAuditLogHandleMissingFilter", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/admin/audit", nil)
+
+		request.Header.Set(adminTokenHeader, testAdminToken)
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusBadRequest, response.StatusCode)
+
+	})
+
+	t.Run("TestGetAuditLogHandleInvalidTime", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/admin/audit?userId=1&from=dun", nil)
+
+		request.Header.Set(adminTokenHeader, testAdminToken)
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusBadRequest, response.StatusCode)
+
+	})
+
+	t.Run("TestGetAuditLogHandleUnauthorized", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/admin/audit?userId=1", nil)
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusUnauthorized, response.StatusCode)
+
+	})
+
+}
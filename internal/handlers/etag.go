@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// listETag listenin versiyonundan üretilen güçlü ETag'dir. Versiyon liste ya da ürünleri her
+// değiştiğinde arttığından If-Match ile eşzamanlı yazmaları ayırt etmek için kullanılır.
+func listETag(listId int, version int) string {
+	return fmt.Sprintf("\"list-%d-v%d\"", listId, version)
+}
+
+// parseIfMatch If-Match header'ını listenin beklenen versiyonuna çevirir. Header yoksa veya "*"
+// ise koşulsuz yazma için nil döner. Header bu listenin güçlü ETag'ini içermiyorsa ok false döner.
+func parseIfMatch(c *fiber.Ctx, listId int) (*int, bool) {
+
+	header := strings.TrimSpace(c.Get(fiber.HeaderIfMatch))
+
+	if header == "" || header == "*" {
+		return nil, true
+	}
+
+	prefix := fmt.Sprintf("\"list-%d-v", listId)
+
+	for _, tag := range strings.Split(header, ",") {
+
+		tag = strings.TrimSpace(tag)
+
+		if !strings.HasPrefix(tag, prefix) || !strings.HasSuffix(tag, "\"") {
+			continue
+		}
+
+		version, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(tag, prefix), "\""))
+		if err != nil {
+			continue
+		}
+
+		return &version, true
+	}
+
+	return nil, false
+}
+
+// writeConditional yazılmış 200 yanıtına ETag ekler; etag boşsa gövdeden zayıf bir ETag üretilir.
+// If-None-Match eşleşirse gövde atılır ve 304 döner.
+func writeConditional(c *fiber.Ctx, etag string) error {
+
+	if c.Response().StatusCode() != fiber.StatusOK {
+		return nil
+	}
+
+	if etag == "" {
+		etag = fmt.Sprintf("W/\"%s\"", sha256Hex(c.Response().Body())[:32])
+	}
+
+	c.Set(fiber.HeaderETag, etag)
+
+	if etagMatches(c.Get(fiber.HeaderIfNoneMatch), etag) {
+		c.Response().ResetBody()
+		c.Status(fiber.StatusNotModified)
+	}
+
+	return nil
+}
+
+// etagMatches If-None-Match için zayıf karşılaştırma yapar; W/ öneki yok sayılır.
+func etagMatches(header string, etag string) bool {
+
+	header = strings.TrimSpace(header)
+
+	if header == "" {
+		return false
+	}
+
+	if header == "*" {
+		return true
+	}
+
+	etag = strings.TrimPrefix(etag, "W/")
+
+	for _, tag := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"context"
+	"favorite_service/internal/models"
+	"favorite_service/logs"
+
+	"github.com/go-swagno/swagno/components/endpoint"
+	"github.com/go-swagno/swagno/components/http/response"
+	"github.com/go-swagno/swagno/components/parameter"
+	"github.com/gofiber/fiber/v2"
+)
+
+type favoriteCountService interface {
+	GetFavoriteCounts(ctx context.Context, productIds []int) ([]models.ProductFavoriteCount, error)
+}
+
+type FavoriteCountHandler struct {
+	favoriteCountService favoriteCountService
+	serviceToken         string
+}
+
+func NewFavoriteCountHandler(favoriteCountService favoriteCountService, serviceToken string) *FavoriteCountHandler {
+	return &FavoriteCountHandler{
+		favoriteCountService: favoriteCountService,
+		serviceToken:         serviceToken,
+	}
+}
+
+func (h *FavoriteCountHandler) GetFavoriteCountsHandle(c *fiber.Ctx) error {
+
+	request := models.FavoriteCountsRequest{}
+
+	if err := c.BodyParser(&request); err != nil {
+
+		logs.Error(err.Error(),
+			logs.WithHandlerName("FavoriteCountHandler_GetFavoriteCounts"),
+			logs.WithStatus(fiber.StatusBadRequest),
+		)
+
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Body Parse Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	if err := request.Validate(); err != nil {
+
+		logs.Warning(err.Error(),
+			logs.WithHandlerName("FavoriteCountHandler_GetFavoriteCounts"),
+			logs.WithStatus(fiber.StatusBadRequest),
+		)
+
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Validate Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	ctx := c.UserContext()
+
+	counts, err := h.favoriteCountService.GetFavoriteCounts(ctx, request.ProductIds)
+
+	if err != nil {
+
+		logs.Error(err.Error(),
+			logs.WithHandlerName("FavoriteCountHandler_GetFavoriteCounts"),
+			logs.WithStatus(fiber.StatusInternalServerError),
+		)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErorResponse{
+			Error:   "Servis Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(models.SuccesResponse{SuccesData: counts})
+
+}
+
+func (h *FavoriteCountHandler) SetRoutes(app *fiber.App) {
+
+	internalGroup := app.Group("/internal", RequireServiceToken(h.serviceToken))
+
+	internalGroup.Post("/products/favorite-counts", h.GetFavoriteCountsHandle)
+
+}
+
+func FavoriteCountGetEndpoints() []*endpoint.EndPoint {
+	return []*endpoint.EndPoint{
+		endpoint.New(
+			endpoint.POST,
+			"/internal/products/favorite-counts",
+			endpoint.WithTags("internal"),
+			endpoint.WithParams(parameter.StrParam(serviceTokenHeader, parameter.Header, parameter.WithRequired())),
+			endpoint.WithBody(models.FavoriteCountsRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New([]models.ProductFavoriteCount{}, "200", "OK")}),
+			endpoint.WithErrors([]response.Response{response.New(models.ErorResponse{}, "400", "Bad Request")}),
+		),
+	}
+}
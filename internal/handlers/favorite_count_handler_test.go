@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"favorite_service/internal/models"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFavoriteCountHandler(t *testing.T) {
+
+	t.Run("TestGetFavoriteCountsHandle", func(t *testing.T) {
+
+		body, err := json.Marshal(models.FavoriteCountsRequest{ProductIds: []int{1, 2, 999}})
+
+		assert.Nil(t, err)
+
+		request := httptest.NewRequest("POST", "/internal/products/favorite-counts", bytes.NewReader(body))
+
+		request.Header.Set("Content-Type", "application/json")
+
+		request.Header.Set(serviceTokenHeader, testServiceToken)
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+		var result struct {
+			SuccesData []models.ProductFavoriteCount
+		}
+
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&result))
+
+		assert.Len(t, result.SuccesData, 3)
+
+		assert.Equal(t, int64(0), result.SuccesData[2].FavoriteCount)
+
+	})
+
+	t.Run("TestGetFavoriteCountsHandleUnauthorized", func(t *testing.T) {
+
+		body, err := json.Marshal(models.FavoriteCountsRequest{ProductIds: []int{1}})
+
+		assert.Nil(t, err)
+
+		request := httptest.NewRequest("POST", "/internal/products/favorite-counts", bytes.NewReader(body))
+
+		request.Header.Set("Content-Type", "application/json")
+
+		request.Header.Set(serviceTokenHeader, "wrong-token")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusUnauthorized, response.StatusCode)
+
+	})
+
+}
@@ -2,9 +2,12 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"favorite_service/internal/models"
+	"favorite_service/logs"
+	"fmt"
 	"strconv"
-	"time"
+	"strings"
 
 	"github.com/go-swagno/swagno/components/endpoint"
 	"github.com/go-swagno/swagno/components/http/response"
@@ -13,33 +16,30 @@ import (
 )
 
 type favoriteItemService interface {
-	GetFavoriteItem(listId int, token string, ctx context.Context) ([]models.Product, error)
+	GetFavoriteItem(listId int, token string, ctx context.Context) ([]models.FavoriteProduct, error)
+	GetFavoriteItems(listId int, include models.ResponseInclude, token string, ctx context.Context) (models.FavoriteItemsResponse, error)
 	CreateFavoriteItem(item models.CreateFavoriteItem, token string, ctx context.Context) (models.FavoriteItem, error)
 	DeleteFavoriteItem(listId int, itemId int, token string, ctx context.Context) error
+	ContainsFavoriteItems(productIds []int, token string, ctx context.Context) ([]models.FavoriteContainsResponse, error)
+	ReorderFavoriteItems(listId int, itemIds []int, token string, ctx context.Context) ([]models.FavoriteItem, error)
+	PatchFavoriteItem(listId int, itemId int, patch models.PatchFavoriteItem, token string, ctx context.Context) (models.FavoriteItem, error)
+	ToggleDefaultFavorite(productId int, token string, ctx context.Context) (models.FavoriteToggleResponse, error)
 }
 
-type metric interface {
-	ObserveHandler(name string, startTime time.Time, status int)
-}
+const maxContainsProductIds = 100
 
 type FavoriteItemHandler struct {
 	favoriteItemService favoriteItemService
-	metric              metric
 }
 
-func NewFavoriteItemHandler(favoriteItemRepository favoriteItemService, metric metric) *FavoriteItemHandler {
+func NewFavoriteItemHandler(favoriteItemRepository favoriteItemService) *FavoriteItemHandler {
 	return &FavoriteItemHandler{
 		favoriteItemService: favoriteItemRepository,
-		metric:              metric,
 	}
 }
 
 func (h *FavoriteItemHandler) GetFavoriteItemHandle(c *fiber.Ctx) error {
 
-	defer func() {
-		h.metric.ObserveHandler("FavoriteItemHandler_GetFavoriteItem", time.Now(), c.Response().StatusCode())
-	}()
-
 	listId, err := c.ParamsInt("listId")
 
 	if err != nil {
@@ -58,9 +58,58 @@ func (h *FavoriteItemHandler) GetFavoriteItemHandle(c *fiber.Ctx) error {
 		})
 	}
 
+	fields, err := parseFields(c.Query("fields"))
+
+	if err != nil {
+
+		logs.Warning(err.Error(),
+			logs.WithHandlerName("GetFavoriteItemHandle"),
+			logs.WithStatus(fiber.StatusBadRequest),
+		)
+
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Query Parametre Hatasi",
+			Details: err.Error()},
+		)
+	}
+
 	ctx := c.UserContext()
 
-	itemList, err := h.favoriteItemService.GetFavoriteItem(listId, autHeader, ctx)
+	// include verilmezse eski yanıt biçimi (ürün dizisi) korunur.
+	if c.Query("include") == "" {
+
+		itemList, err := h.favoriteItemService.GetFavoriteItem(listId, autHeader, ctx)
+
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(models.ErorResponse{
+				Error:   "Servis Hatası",
+				Details: err.Error()},
+			)
+		}
+
+		if fields != nil {
+			return c.Status(fiber.StatusOK).JSON(models.SuccesResponse{SuccesData: sparseProducts(itemList, fields)})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(models.SuccesResponse{SuccesData: itemList})
+	}
+
+	include, err := parseInclude(c.Query("include"))
+
+	if err != nil {
+
+		logs.Warning(err.Error(),
+			logs.WithHandlerName("GetFavoriteItemHandle"),
+			logs.WithStatus(fiber.StatusBadRequest),
+		)
+
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Query Parametre Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	items, err := h.favoriteItemService.GetFavoriteItems(listId, include, autHeader, ctx)
 
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErorResponse{
@@ -69,16 +118,27 @@ func (h *FavoriteItemHandler) GetFavoriteItemHandle(c *fiber.Ctx) error {
 		)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(models.SuccesResponse{SuccesData: itemList})
+	if fields != nil && items.Products != nil {
+
+		sparse := fiber.Map{"list_id": items.ListId, "products": sparseProducts(items.Products, fields)}
+
+		if items.ItemIds != nil {
+			sparse["item_ids"] = items.ItemIds
+		}
+
+		if items.ItemCount != nil {
+			sparse["item_count"] = *items.ItemCount
+		}
+
+		return c.Status(fiber.StatusOK).JSON(models.SuccesResponse{SuccesData: sparse})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(models.SuccesResponse{SuccesData: items})
 
 }
 
 func (h *FavoriteItemHandler) CreateFavoriteItemHandle(c *fiber.Ctx) error {
 
-	defer func() {
-		h.metric.ObserveHandler("FavoriteItemHandler_CreateFavoriteItem", time.Now(), c.Response().StatusCode())
-	}()
-
 	newItem := models.CreateFavoriteItem{}
 
 	if err := c.BodyParser(&newItem); err != nil {
@@ -112,6 +172,18 @@ func (h *FavoriteItemHandler) CreateFavoriteItemHandle(c *fiber.Ctx) error {
 
 	if err != nil {
 
+		var quotaErr *models.QuotaExceededError
+		if errors.As(err, &quotaErr) {
+			return writeQuotaExceeded(c, "CreateFavoriteItemHandle", quotaErr)
+		}
+
+		if errors.Is(err, models.ErrProductNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErorResponse{
+				Error:   "Ürün Bulunamadi",
+				Details: err.Error()},
+			)
+		}
+
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErorResponse{
 			Error:   "Servis Hatasi",
 			Details: err.Error()},
@@ -124,10 +196,6 @@ func (h *FavoriteItemHandler) CreateFavoriteItemHandle(c *fiber.Ctx) error {
 
 func (h *FavoriteItemHandler) DeleteFavoriteItemHandle(c *fiber.Ctx) error {
 
-	defer func() {
-		h.metric.ObserveHandler("FavoriteItemHandler_DeleteFavoriteItem", time.Now(), c.Response().StatusCode())
-	}()
-
 	listId, err := c.ParamsInt("listId")
 
 	if err != nil {
@@ -177,13 +245,273 @@ func (h *FavoriteItemHandler) DeleteFavoriteItemHandle(c *fiber.Ctx) error {
 
 }
 
-func (h *FavoriteItemHandler) SetRoutes(app *fiber.App) {
+func (h *FavoriteItemHandler) PatchFavoriteItemHandle(c *fiber.Ctx) error {
+
+	listId, err := c.ParamsInt("listId")
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Parametre Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	itemId, err := strconv.Atoi(c.Query("itemId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Query Parametre Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	patch := models.PatchFavoriteItem{}
+
+	if err := c.BodyParser(&patch); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Body Parse Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	if err := patch.Validate(); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Validate Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	autHeader := c.Get("Authorization")
+
+	if autHeader == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErorResponse{
+			Error:   "Token Authorization Hatasi",
+			Details: "Token"},
+		)
+	}
+
+	ctx := c.UserContext()
+
+	favoriteItem, err := h.favoriteItemService.PatchFavoriteItem(listId, itemId, patch, autHeader, ctx)
+
+	if err != nil {
+
+		status := fiber.StatusInternalServerError
+
+		switch {
+		case errors.Is(err, models.ErrRecordNotFound):
+			status = fiber.StatusNotFound
+		case errors.Is(err, models.ErrunaUthorizedAction):
+			status = fiber.StatusForbidden
+		case errors.Is(err, models.ErrUserUnauthorized):
+			status = fiber.StatusUnauthorized
+		}
+
+		return c.Status(status).JSON(models.ErorResponse{
+			Error:   "Servis Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(models.SuccesResponse{SuccesData: favoriteItem})
+
+}
+
+func (h *FavoriteItemHandler) ReorderFavoriteItemsHandle(c *fiber.Ctx) error {
 
-	itemGroup := app.Group("/items")
+	listId, err := c.ParamsInt("listId")
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "List Id Bulunamadi",
+			Details: err.Error()},
+		)
+	}
+
+	order := models.ReorderFavoriteItems{}
+
+	if err := c.BodyParser(&order); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Body Parse Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	if err := order.Validate(); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Validate Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	autHeader := c.Get("Authorization")
+
+	if autHeader == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErorResponse{
+			Error:   "Token Authorization Hatasi",
+			Details: "Token"},
+		)
+	}
+
+	ctx := c.UserContext()
+
+	items, err := h.favoriteItemService.ReorderFavoriteItems(listId, order.ItemIds, autHeader, ctx)
+
+	if err != nil {
+
+		status := fiber.StatusInternalServerError
+
+		switch {
+		case errors.Is(err, models.ErrRecordNotFound), errors.Is(err, models.ErrDuplicateItem):
+			status = fiber.StatusBadRequest
+		case errors.Is(err, models.ErrunaUthorizedAction):
+			status = fiber.StatusForbidden
+		case errors.Is(err, models.ErrUserUnauthorized):
+			status = fiber.StatusUnauthorized
+		}
+
+		return c.Status(status).JSON(models.ErorResponse{
+			Error:   "Servis Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(models.SuccesResponse{SuccesData: items})
+
+}
+
+// ToggleDefaultFavoriteHandle ürünü kullanıcının varsayılan listesine ekler ya da listeden çıkarır.
+func (h *FavoriteItemHandler) ToggleDefaultFavoriteHandle(c *fiber.Ctx) error {
+
+	productId, err := c.ParamsInt("productId")
+
+	if err != nil || productId <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Parametre Hatasi",
+			Details: "geçersiz productId"},
+		)
+	}
+
+	autHeader := c.Get("Authorization")
+
+	if autHeader == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErorResponse{
+			Error:   "Token Authorization Hatasi",
+			Details: "Token"},
+		)
+	}
+
+	ctx := c.UserContext()
+
+	toggle, err := h.favoriteItemService.ToggleDefaultFavorite(productId, autHeader, ctx)
+
+	if err != nil {
+
+		var quotaErr *models.QuotaExceededError
+		if errors.As(err, &quotaErr) {
+			return writeQuotaExceeded(c, "ToggleDefaultFavoriteHandle", quotaErr)
+		}
+
+		status := fiber.StatusInternalServerError
+
+		switch {
+		case errors.Is(err, models.ErrUserUnauthorized), errors.Is(err, models.ErrUserNotFound):
+			status = fiber.StatusUnauthorized
+		case errors.Is(err, models.ErrProductNotFound), errors.Is(err, models.ErrRecordNotFound):
+			status = fiber.StatusNotFound
+		}
+
+		return c.Status(status).JSON(models.ErorResponse{
+			Error:   "Servis Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(models.SuccesResponse{SuccesData: toggle})
+
+}
+
+func (h *FavoriteItemHandler) ContainsFavoriteItemsHandle(c *fiber.Ctx) error {
+
+	productIds, err := parseIdList(c.Query("productIds"), maxContainsProductIds)
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Query Parametre Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	autHeader := c.Get("Authorization")
+
+	if autHeader == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErorResponse{
+			Error:   "Token Authorization Hatasi",
+			Details: "Token"},
+		)
+	}
+
+	ctx := c.UserContext()
+
+	contains, err := h.favoriteItemService.ContainsFavoriteItems(productIds, autHeader, ctx)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErorResponse{
+			Error:   "Servis Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(models.SuccesResponse{SuccesData: contains})
+
+}
+
+// parseIdList "1,2,3" biçimindeki id listesini tekrarları atarak sırasını koruyarak çözer.
+func parseIdList(value string, max int) ([]int, error) {
+
+	if value == "" {
+		return nil, fmt.Errorf("en az bir id zorunlu")
+	}
+
+	parts := strings.Split(value, ",")
+
+	ids := make([]int, 0, len(parts))
+	seen := make(map[int]struct{}, len(parts))
+
+	for _, part := range parts {
+		id, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("geçersiz id: %q", part)
+		}
+
+		if _, ok := seen[id]; ok {
+			continue
+		}
+
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	if len(ids) > max {
+		return nil, fmt.Errorf("en fazla %d id gönderilebilir", max)
+	}
+
+	return ids, nil
+}
+
+func (h *FavoriteItemHandler) SetRoutes(router fiber.Router) {
+
+	itemGroup := router.Group("/items")
 
 	itemGroup.Get("/:listId", h.GetFavoriteItemHandle)
 	itemGroup.Post("", h.CreateFavoriteItemHandle)
 	itemGroup.Delete("/:listId/item", h.DeleteFavoriteItemHandle)
+	itemGroup.Patch("/:listId/item", h.PatchFavoriteItemHandle)
+	itemGroup.Put("/:listId/order", h.ReorderFavoriteItemsHandle)
+
+	favoriteGroup := router.Group("/favorites")
+
+	favoriteGroup.Get("/contains", h.ContainsFavoriteItemsHandle)
+	favoriteGroup.Post("/:productId", h.ToggleDefaultFavoriteHandle)
 
 }
 
@@ -194,8 +522,10 @@ func ItemGetEndpoints() []*endpoint.EndPoint {
 			"/items/{listId}",
 			endpoint.WithTags("item"),
 			endpoint.WithParams(parameter.IntParam("listId", parameter.Path, parameter.WithRequired())),
+			endpoint.WithParams(parameter.StrParam("include", parameter.Query, parameter.WithDescription("items,counts,products; verilirse yanıt FavoriteItemsResponse olur"))),
+			endpoint.WithParams(parameter.StrParam("fields", parameter.Query, parameter.WithDescription("id,name,price,stock,note,quantity,priority,variant"))),
 			endpoint.WithParams(parameter.StrParam("Authorization", parameter.Header, parameter.WithRequired())),
-			endpoint.WithSuccessfulReturns([]response.Response{response.New([]models.Product{}, "200", "OK")}),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New([]models.FavoriteProduct{}, "200", "OK")}),
 			endpoint.WithErrors([]response.Response{response.New(models.ErorResponse{}, "400", "Bad Request")}),
 		),
 
@@ -219,5 +549,48 @@ func ItemGetEndpoints() []*endpoint.EndPoint {
 			endpoint.WithSuccessfulReturns([]response.Response{response.New(models.SuccesResponse{}, "200", "OK")}),
 			endpoint.WithErrors([]response.Response{response.New(models.ErorResponse{}, "400", "Bad Request")}),
 		),
+
+		endpoint.New(
+			endpoint.PATCH,
+			"/items/{listId}/item",
+			endpoint.WithTags("item"),
+			endpoint.WithParams(parameter.IntParam("listId", parameter.Path, parameter.WithRequired())),
+			endpoint.WithParams(parameter.IntParam("itemId", parameter.Query, parameter.WithRequired())),
+			endpoint.WithParams(parameter.StrParam("Authorization", parameter.Header, parameter.WithRequired())),
+			endpoint.WithBody(models.PatchFavoriteItem{}),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New(models.FavoriteItem{}, "200", "OK")}),
+			endpoint.WithErrors([]response.Response{response.New(models.ErorResponse{}, "400", "Bad Request")}),
+		),
+
+		endpoint.New(
+			endpoint.PUT,
+			"/items/{listId}/order",
+			endpoint.WithTags("item"),
+			endpoint.WithParams(parameter.IntParam("listId", parameter.Path, parameter.WithRequired())),
+			endpoint.WithParams(parameter.StrParam("Authorization", parameter.Header, parameter.WithRequired())),
+			endpoint.WithBody(models.ReorderFavoriteItems{}),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New([]models.FavoriteItem{}, "200", "OK")}),
+			endpoint.WithErrors([]response.Response{response.New(models.ErorResponse{}, "400", "Bad Request")}),
+		),
+
+		endpoint.New(
+			endpoint.GET,
+			"/favorites/contains",
+			endpoint.WithTags("item"),
+			endpoint.WithParams(parameter.StrParam("productIds", parameter.Query, parameter.WithRequired(), parameter.WithDescription("Virgülle ayrılmış ürün id listesi"))),
+			endpoint.WithParams(parameter.StrParam("Authorization", parameter.Header, parameter.WithRequired())),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New([]models.FavoriteContainsResponse{}, "200", "OK")}),
+			endpoint.WithErrors([]response.Response{response.New(models.ErorResponse{}, "400", "Bad Request")}),
+		),
+
+		endpoint.New(
+			endpoint.POST,
+			"/favorites/{productId}",
+			endpoint.WithTags("item"),
+			endpoint.WithParams(parameter.IntParam("productId", parameter.Path, parameter.WithRequired())),
+			endpoint.WithParams(parameter.StrParam("Authorization", parameter.Header, parameter.WithRequired())),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New(models.FavoriteToggleResponse{}, "200", "OK")}),
+			endpoint.WithErrors([]response.Response{response.New(models.ErorResponse{}, "400", "Bad Request")}),
+		),
 	}
 }
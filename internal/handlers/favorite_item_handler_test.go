@@ -27,6 +27,46 @@ func TestFavoriteItemHandler(t *testing.T) {
 
 	})
 
+	t.Run("TestGetFavoriteItemHandleIdsAndCounts", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/items/1?include=items,counts", nil)
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+		var body struct {
+			SuccesData models.FavoriteItemsResponse
+		}
+
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&body))
+
+		assert.NotNil(t, body.SuccesData.ItemCount)
+
+		assert.Len(t, body.SuccesData.ItemIds, *body.SuccesData.ItemCount)
+
+		assert.Nil(t, body.SuccesData.Products)
+
+	})
+
+	t.Run("TestGetFavoriteItemHandleInvalidFields", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/items/1?fields=color", nil)
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusBadRequest, response.StatusCode)
+
+	})
+
 	t.Run("TestGetFavoriteItemHandleNotFound", func(t *testing.T) {
 
 		request := httptest.NewRequest("GET", "/items/999", nil)
@@ -91,6 +131,144 @@ func TestFavoriteItemHandler(t *testing.T) {
 
 	})
 
+	t.Run("TestCreateFavoriteItemHandleProductNotFound", func(t *testing.T) {
+
+		body, err := json.Marshal(models.CreateFavoriteItem{ItemId: 99, ListId: 1})
+
+		assert.Nil(t, err)
+
+		request := httptest.NewRequest("POST", "/items", bytes.NewReader(body))
+
+		request.Header.Set("Content-Type", "application/json")
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusNotFound, response.StatusCode)
+
+	})
+
+	t.Run("TestPatchFavoriteItemHandle", func(t *testing.T) {
+
+		body := []byte(`{"note":"Doğum günü hediyesi","quantity":2,"priority":"must_have","variant":"XL / Siyah"}`)
+
+		request := httptest.NewRequest("PATCH", "/items/1/item?itemId=10", bytes.NewReader(body))
+
+		request.Header.Set("Content-Type", "application/json")
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+		var result struct {
+			SuccesData models.FavoriteItem
+		}
+
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&result))
+
+		assert.Equal(t, 2, result.SuccesData.Quantity)
+
+		assert.Equal(t, models.PriorityMustHave, result.SuccesData.Priority)
+
+	})
+
+	t.Run("TestPatchFavoriteItemHandleInvalidPriority", func(t *testing.T) {
+
+		body := []byte(`{"priority":"someday"}`)
+
+		request := httptest.NewRequest("PATCH", "/items/1/item?itemId=10", bytes.NewReader(body))
+
+		request.Header.Set("Content-Type", "application/json")
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusBadRequest, response.StatusCode)
+
+	})
+
+	t.Run("TestPatchFavoriteItemHandleNotFound", func(t *testing.T) {
+
+		body := []byte(`{"quantity":3}`)
+
+		request := httptest.NewRequest("PATCH", "/items/1/item?itemId=999", bytes.NewReader(body))
+
+		request.Header.Set("Content-Type", "application/json")
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusNotFound, response.StatusCode)
+
+	})
+
+	t.Run("TestReorderFavoriteItemsHandle", func(t *testing.T) {
+
+		body, err := json.Marshal(models.ReorderFavoriteItems{ItemIds: []int{10}})
+
+		assert.Nil(t, err)
+
+		request := httptest.NewRequest("PUT", "/items/1/order", bytes.NewReader(body))
+
+		request.Header.Set("Content-Type", "application/json")
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+		var result struct {
+			SuccesData []models.FavoriteItem
+		}
+
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&result))
+
+		assert.NotEmpty(t, result.SuccesData)
+
+		assert.Equal(t, 10, result.SuccesData[0].ItemId)
+
+		for i, item := range result.SuccesData {
+			assert.Equal(t, i+1, item.Position)
+		}
+
+	})
+
+	t.Run("TestReorderFavoriteItemsHandleUnknownItem", func(t *testing.T) {
+
+		body, err := json.Marshal(models.ReorderFavoriteItems{ItemIds: []int{999}})
+
+		assert.Nil(t, err)
+
+		request := httptest.NewRequest("PUT", "/items/1/order", bytes.NewReader(body))
+
+		request.Header.Set("Content-Type", "application/json")
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusBadRequest, response.StatusCode)
+
+	})
+
 	t.Run("TestDeleteFavoriteItemHandle", func(t *testing.T) {
 
 		request := httptest.NewRequest("DELETE", "/items/1/item?itemId=1", nil)
@@ -115,4 +293,109 @@ func TestFavoriteItemHandler(t *testing.T) {
 		assert.Equal(t, fiber.StatusUnauthorized, response.StatusCode)
 	})
 
+	t.Run("TestToggleDefaultFavoriteHandle", func(t *testing.T) {
+
+		toggle := func() models.FavoriteToggleResponse {
+
+			request := httptest.NewRequest("POST", "/favorites/7", nil)
+
+			request.Header.Set("Authorization", "1")
+
+			response, err := app.Test(request)
+
+			assert.Nil(t, err)
+
+			assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+			var body struct {
+				SuccesData models.FavoriteToggleResponse
+			}
+
+			assert.Nil(t, json.NewDecoder(response.Body).Decode(&body))
+
+			return body.SuccesData
+		}
+
+		added := toggle()
+
+		assert.True(t, added.Favorited)
+
+		removed := toggle()
+
+		assert.False(t, removed.Favorited)
+
+		assert.Equal(t, added.ListId, removed.ListId)
+
+	})
+
+	t.Run("TestToggleDefaultFavoriteHandleProductNotFound", func(t *testing.T) {
+
+		request := httptest.NewRequest("POST", "/favorites/99", nil)
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusNotFound, response.StatusCode)
+
+	})
+
+	t.Run("TestCreateFavoriteItemHandleDefaultList", func(t *testing.T) {
+
+		body, err := json.Marshal(models.CreateFavoriteItem{ItemId: 8})
+
+		assert.Nil(t, err)
+
+		request := httptest.NewRequest("POST", "/items", bytes.NewReader(body))
+
+		request.Header.Set("Content-Type", "application/json")
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+	})
+
+	t.Run("TestContainsFavoriteItemsHandle", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/favorites/contains?productIds=1,2,3", nil)
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+		var body struct {
+			SuccesData []models.FavoriteContainsResponse
+		}
+
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&body))
+
+		assert.Len(t, body.SuccesData, 3)
+
+	})
+
+	t.Run("TestContainsFavoriteItemsHandleBadRequest", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/favorites/contains?productIds=1,abc", nil)
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusBadRequest, response.StatusCode)
+
+	})
+
 }
@@ -2,9 +2,9 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"favorite_service/internal/models"
 	"favorite_service/logs"
-	"time"
 
 	"github.com/go-swagno/swagno/components/endpoint"
 	"github.com/go-swagno/swagno/components/http/response"
@@ -14,33 +14,27 @@ import (
 
 type favoriteListService interface {
 	GetUserFavoriteListsWithItems(token string, ctx context.Context) ([]models.FavoriteListResponse, error)
+	GetUserFavoriteLists(include models.ResponseInclude, filter models.FavoriteListFilter, token string, ctx context.Context) ([]models.FavoriteListResponse, error)
+	PatchFavoriteList(listId int, patch models.PatchFavoriteList, expectedVersion *int, token string, ctx context.Context) (models.FavoriteList, error)
+	SetDefaultList(listId int, token string, ctx context.Context) (models.FavoriteList, error)
+	GetFavoriteList(listId int, includeProducts bool, token string, ctx context.Context) (models.FavoriteListDetail, error)
 	CreateFavoriteList(list *models.FavoriteList, token string, ctx context.Context) error
-	UpdateFavoriteList(listId int, list models.UpdateFavoriteList, token string, ctx context.Context) (models.FavoriteList, error)
-	DeleteFavoriteList(listId int, token string, ctx context.Context) error
-}
-
-type metrics interface {
-	ObserveHandler(name string, startTime time.Time, status int)
+	UpdateFavoriteList(listId int, list models.UpdateFavoriteList, expectedVersion *int, token string, ctx context.Context) (models.FavoriteList, error)
+	DeleteFavoriteList(listId int, expectedVersion *int, token string, ctx context.Context) error
 }
 
 type FavoriteListHandler struct {
 	favoriteListService favoriteListService
-	metrics             metrics
 }
 
-func NewFavoriteListHandler(favoriteListService favoriteListService, metrics metrics) *FavoriteListHandler {
+func NewFavoriteListHandler(favoriteListService favoriteListService) *FavoriteListHandler {
 	return &FavoriteListHandler{
 		favoriteListService: favoriteListService,
-		metrics:             metrics,
 	}
 }
 
 func (h *FavoriteListHandler) GetUserFavoriteListsWithItemsHandle(c *fiber.Ctx) error {
 
-	defer func() {
-		h.metrics.ObserveHandler("FavoriteListHandler_GetFavoriteList", time.Now(), c.Response().StatusCode())
-	}()
-
 	authHeader := c.Get("Authorization")
 	if authHeader == "" {
 
@@ -55,9 +49,55 @@ func (h *FavoriteListHandler) GetUserFavoriteListsWithItemsHandle(c *fiber.Ctx)
 		)
 	}
 
+	include, err := parseInclude(c.Query("include"))
+	if err != nil {
+
+		logs.Warning(err.Error(),
+			logs.WithHandlerName("FavoriteListHandler_GetFavoriteList"),
+			logs.WithStatus(fiber.StatusBadRequest),
+		)
+
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Query Parametre Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	fields, err := parseFields(c.Query("fields"))
+	if err != nil {
+
+		logs.Warning(err.Error(),
+			logs.WithHandlerName("FavoriteListHandler_GetFavoriteList"),
+			logs.WithStatus(fiber.StatusBadRequest),
+		)
+
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Query Parametre Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	filter := models.FavoriteListFilter{
+		ListType:   c.Query("type"),
+		Visibility: c.Query("visibility"),
+	}
+
+	if err := filter.Validate(); err != nil {
+
+		logs.Warning(err.Error(),
+			logs.WithHandlerName("FavoriteListHandler_GetFavoriteList"),
+			logs.WithStatus(fiber.StatusBadRequest),
+		)
+
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Query Parametre Hatasi",
+			Details: err.Error()},
+		)
+	}
+
 	ctx := c.UserContext()
 
-	favoriteList, err := h.favoriteListService.GetUserFavoriteListsWithItems(authHeader, ctx)
+	favoriteList, err := h.favoriteListService.GetUserFavoriteLists(include, filter, authHeader, ctx)
 
 	if err != nil {
 		if err == models.ErrUserNotFound {
@@ -90,15 +130,139 @@ func (h *FavoriteListHandler) GetUserFavoriteListsWithItemsHandle(c *fiber.Ctx)
 		logs.WithStatus(fiber.StatusOK),
 	)
 
-	return c.Status(fiber.StatusOK).JSON(models.SuccesResponse{SuccesData: favoriteList})
+	if fields != nil {
+		if err := c.Status(fiber.StatusOK).JSON(models.SuccesResponse{SuccesData: sparseLists(favoriteList, fields)}); err != nil {
+			return err
+		}
+
+		return writeConditional(c, "")
+	}
+
+	if err := c.Status(fiber.StatusOK).JSON(models.SuccesResponse{SuccesData: favoriteList}); err != nil {
+		return err
+	}
+
+	return writeConditional(c, "")
 
 }
 
-func (h *FavoriteListHandler) CreateFavoriteListHandle(c *fiber.Ctx) error {
+func (h *FavoriteListHandler) GetFavoriteListHandle(c *fiber.Ctx) error {
+
+	listId, err := c.ParamsInt("listId")
+
+	if err != nil {
+
+		logs.Warning(err.Error(),
+			logs.WithHandlerName("GetFavoriteListHandle"),
+			logs.WithStatus(fiber.StatusBadRequest),
+		)
+
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "List Id Bulunamadi",
+			Details: err.Error()},
+		)
+	}
+
+	authHeader := c.Get("Authorization")
+	if authHeader == "" {
+
+		logs.Warning("Token Authorization Hatasi",
+			logs.WithHandlerName("GetFavoriteListHandle"),
+			logs.WithStatus(fiber.StatusUnauthorized),
+		)
+
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErorResponse{
+			Error:   "Token Authorization Hatasi",
+			Details: "Token"},
+		)
+	}
+
+	// include verilmezse ürünler getirilmez; yanıt listenin versiyonundan üretilen ETag ile önbelleklenebilir.
+	include := models.ResponseInclude{}
+
+	if c.Query("include") != "" {
+
+		include, err = parseInclude(c.Query("include"))
+		if err != nil {
+
+			logs.Warning(err.Error(),
+				logs.WithHandlerName("GetFavoriteListHandle"),
+				logs.WithStatus(fiber.StatusBadRequest),
+			)
+
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+				Error:   "Query Parametre Hatasi",
+				Details: err.Error()},
+			)
+		}
+	}
+
+	fields, err := parseFields(c.Query("fields"))
+	if err != nil {
+
+		logs.Warning(err.Error(),
+			logs.WithHandlerName("GetFavoriteListHandle"),
+			logs.WithStatus(fiber.StatusBadRequest),
+		)
+
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Query Parametre Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	ctx := c.UserContext()
+
+	favoriteList, err := h.favoriteListService.GetFavoriteList(listId, include.Products, authHeader, ctx)
+
+	if err != nil {
+
+		status, message := fiber.StatusInternalServerError, "Servis Hatasi"
+
+		switch {
+		case errors.Is(err, models.ErrRecordNotFound):
+			status, message = fiber.StatusNotFound, "Liste bulunamadı"
+		case errors.Is(err, models.ErrunaUthorizedAction):
+			status, message = fiber.StatusForbidden, "Yetkisiz İşlem"
+		case errors.Is(err, models.ErrUserUnauthorized), errors.Is(err, models.ErrUserNotFound):
+			status, message = fiber.StatusUnauthorized, "Token Authorization Hatasi"
+		}
+
+		logs.Warning(err.Error(),
+			logs.WithHandlerName("GetFavoriteListHandle"),
+			logs.WithStatus(status),
+		)
+
+		return c.Status(status).JSON(models.ErorResponse{
+			Error:   message,
+			Details: err.Error()},
+		)
+	}
+
+	logs.Info("Favorite List Başarılı Şekilde Getirildi",
+		logs.WithHandlerName("GetFavoriteListHandle"),
+		logs.WithStatus(fiber.StatusOK),
+	)
 
-	defer func() {
-		h.metrics.ObserveHandler("FavoriteListHandler_CreateFavoriteList", time.Now(), c.Response().StatusCode())
-	}()
+	var data interface{} = favoriteList
+	if fields != nil && include.Products {
+		data = sparseListDetail(favoriteList, fields)
+	}
+
+	if err := c.Status(fiber.StatusOK).JSON(models.SuccesResponse{SuccesData: data}); err != nil {
+		return err
+	}
+
+	// Ürün bilgileri product servisinden geldiğinden versiyon onları kapsamaz; bu durumda gövdeden ETag üretilir.
+	if include.Products {
+		return writeConditional(c, "")
+	}
+
+	return writeConditional(c, listETag(favoriteList.ListId, favoriteList.Version))
+
+}
+
+func (h *FavoriteListHandler) CreateFavoriteListHandle(c *fiber.Ctx) error {
 
 	authHeader := c.Get("Authorization")
 
@@ -144,7 +308,8 @@ func (h *FavoriteListHandler) CreateFavoriteListHandle(c *fiber.Ctx) error {
 	}
 
 	favoriteList := models.FavoriteList{
-		ListName: list.ListName,
+		ListName:             list.ListName,
+		FavoriteListMetadata: list.FavoriteListMetadata,
 	}
 
 	ctx := c.UserContext()
@@ -153,6 +318,11 @@ func (h *FavoriteListHandler) CreateFavoriteListHandle(c *fiber.Ctx) error {
 
 	if err != nil {
 
+		var quotaErr *models.QuotaExceededError
+		if errors.As(err, &quotaErr) {
+			return writeQuotaExceeded(c, "CreateFavoriteListHandle", quotaErr)
+		}
+
 		logs.Error(err.Error(),
 			logs.WithHandlerName("CreateFavoriteListHandle"),
 			logs.WithStatus(fiber.StatusInternalServerError),
@@ -169,16 +339,14 @@ func (h *FavoriteListHandler) CreateFavoriteListHandle(c *fiber.Ctx) error {
 		logs.WithStatus(fiber.StatusOK),
 	)
 
+	c.Set(fiber.HeaderETag, listETag(favoriteList.Id, favoriteList.Version))
+
 	return c.Status(fiber.StatusOK).JSON(models.SuccesResponse{SuccesData: favoriteList})
 
 }
 
 func (h *FavoriteListHandler) UpdateFavoriteListHandle(c *fiber.Ctx) error {
 
-	defer func() {
-		h.metrics.ObserveHandler("FavoriteListHandler_UpdateFavoriteList", time.Now(), c.Response().StatusCode())
-	}()
-
 	listId, err := c.ParamsInt("listId")
 
 	if err != nil {
@@ -209,6 +377,11 @@ func (h *FavoriteListHandler) UpdateFavoriteListHandle(c *fiber.Ctx) error {
 
 	}
 
+	expectedVersion, ok := parseIfMatch(c, listId)
+	if !ok {
+		return writePreconditionFailed(c, "UpdateFavoriteListHandle")
+	}
+
 	list := models.UpdateFavoriteList{}
 
 	if err := c.BodyParser(&list); err != nil {
@@ -241,10 +414,14 @@ func (h *FavoriteListHandler) UpdateFavoriteListHandle(c *fiber.Ctx) error {
 
 	ctx := c.UserContext()
 
-	favoriteList, err := h.favoriteListService.UpdateFavoriteList(listId, list, authHeader, ctx)
+	favoriteList, err := h.favoriteListService.UpdateFavoriteList(listId, list, expectedVersion, authHeader, ctx)
 
 	if err != nil {
 
+		if errors.Is(err, models.ErrVersionMismatch) {
+			return writePreconditionFailed(c, "UpdateFavoriteListHandle")
+		}
+
 		logs.Error(err.Error(),
 			logs.WithHandlerName("UpdateFavoriteListHandle"),
 			logs.WithStatus(fiber.StatusInternalServerError),
@@ -261,15 +438,188 @@ func (h *FavoriteListHandler) UpdateFavoriteListHandle(c *fiber.Ctx) error {
 		logs.WithStatus(fiber.StatusOK),
 	)
 
+	c.Set(fiber.HeaderETag, listETag(favoriteList.Id, favoriteList.Version))
+
 	return c.Status(fiber.StatusOK).JSON(models.SuccesResponse{SuccesData: favoriteList})
 
 }
 
-func (h *FavoriteListHandler) DeleteFavoriteListHandle(c *fiber.Ctx) error {
+func (h *FavoriteListHandler) PatchFavoriteListHandle(c *fiber.Ctx) error {
+
+	listId, err := c.ParamsInt("listId")
+
+	if err != nil {
+
+		logs.Warning(err.Error(),
+			logs.WithHandlerName("PatchFavoriteListHandle"),
+			logs.WithStatus(fiber.StatusBadRequest),
+		)
+
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "List Id Bulunamadi",
+			Details: err.Error()},
+		)
+	}
+
+	authHeader := c.Get("Authorization")
+	if authHeader == "" {
+
+		logs.Warning("Token Authorization Hatasi",
+			logs.WithHandlerName("PatchFavoriteListHandle"),
+			logs.WithStatus(fiber.StatusUnauthorized),
+		)
+
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErorResponse{
+			Error:   "Token Authorization Hatasi",
+			Details: "Token"},
+		)
+	}
+
+	expectedVersion, ok := parseIfMatch(c, listId)
+	if !ok {
+		return writePreconditionFailed(c, "PatchFavoriteListHandle")
+	}
+
+	patch := models.PatchFavoriteList{}
+
+	if err := c.BodyParser(&patch); err != nil {
+
+		logs.Error(err.Error(),
+			logs.WithHandlerName("PatchFavoriteListHandle"),
+			logs.WithStatus(fiber.StatusBadRequest),
+		)
+
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Body Parse Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	if err := patch.Validate(); err != nil {
+
+		logs.Warning(err.Error(),
+			logs.WithHandlerName("PatchFavoriteListHandle"),
+			logs.WithStatus(fiber.StatusBadRequest),
+		)
+
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Validate Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	ctx := c.UserContext()
+
+	favoriteList, err := h.favoriteListService.PatchFavoriteList(listId, patch, expectedVersion, authHeader, ctx)
+
+	if err != nil {
+
+		status, message := fiber.StatusInternalServerError, "Servis Hatasi"
+
+		switch {
+		case errors.Is(err, models.ErrVersionMismatch):
+			return writePreconditionFailed(c, "PatchFavoriteListHandle")
+		case errors.Is(err, models.ErrRecordNotFound):
+			status, message = fiber.StatusNotFound, "Liste bulunamadı"
+		case errors.Is(err, models.ErrunaUthorizedAction):
+			status, message = fiber.StatusForbidden, "Yetkisiz İşlem"
+		case errors.Is(err, models.ErrUserUnauthorized), errors.Is(err, models.ErrUserNotFound):
+			status, message = fiber.StatusUnauthorized, "Token Authorization Hatasi"
+		}
+
+		logs.Warning(err.Error(),
+			logs.WithHandlerName("PatchFavoriteListHandle"),
+			logs.WithStatus(status),
+		)
+
+		return c.Status(status).JSON(models.ErorResponse{
+			Error:   message,
+			Details: err.Error()},
+		)
+	}
+
+	logs.Info("Favorite List Başarılı Şekilde Güncellendi",
+		logs.WithHandlerName("PatchFavoriteListHandle"),
+		logs.WithStatus(fiber.StatusOK),
+	)
+
+	c.Set(fiber.HeaderETag, listETag(favoriteList.Id, favoriteList.Version))
+
+	return c.Status(fiber.StatusOK).JSON(models.SuccesResponse{SuccesData: favoriteList})
+
+}
+
+func (h *FavoriteListHandler) SetDefaultListHandle(c *fiber.Ctx) error {
+
+	listId, err := c.ParamsInt("listId")
+
+	if err != nil {
+
+		logs.Warning(err.Error(),
+			logs.WithHandlerName("SetDefaultListHandle"),
+			logs.WithStatus(fiber.StatusBadRequest),
+		)
+
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "List Id Bulunamadi",
+			Details: err.Error()},
+		)
+	}
+
+	authHeader := c.Get("Authorization")
+	if authHeader == "" {
+
+		logs.Warning("Token Authorization Hatasi",
+			logs.WithHandlerName("SetDefaultListHandle"),
+			logs.WithStatus(fiber.StatusUnauthorized),
+		)
+
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErorResponse{
+			Error:   "Token Authorization Hatasi",
+			Details: "Token"},
+		)
+	}
+
+	ctx := c.UserContext()
+
+	favoriteList, err := h.favoriteListService.SetDefaultList(listId, authHeader, ctx)
+
+	if err != nil {
+
+		status, message := fiber.StatusInternalServerError, "Servis Hatasi"
+
+		switch {
+		case errors.Is(err, models.ErrRecordNotFound):
+			status, message = fiber.StatusNotFound, "Liste bulunamadı"
+		case errors.Is(err, models.ErrunaUthorizedAction):
+			status, message = fiber.StatusForbidden, "Yetkisiz İşlem"
+		case errors.Is(err, models.ErrUserUnauthorized), errors.Is(err, models.ErrUserNotFound):
+			status, message = fiber.StatusUnauthorized, "Token Authorization Hatasi"
+		}
+
+		logs.Warning(err.Error(),
+			logs.WithHandlerName("SetDefaultListHandle"),
+			logs.WithStatus(status),
+		)
+
+		return c.Status(status).JSON(models.ErorResponse{
+			Error:   message,
+			Details: err.Error()},
+		)
+	}
+
+	logs.Info("Varsayılan Liste Güncellendi",
+		logs.WithHandlerName("SetDefaultListHandle"),
+		logs.WithStatus(fiber.StatusOK),
+	)
+
+	c.Set(fiber.HeaderETag, listETag(favoriteList.Id, favoriteList.Version))
+
+	return c.Status(fiber.StatusOK).JSON(models.SuccesResponse{SuccesData: favoriteList})
 
-	defer func() {
-		h.metrics.ObserveHandler("FavoriteListHandler_DeleteFavoriteList", time.Now(), c.Response().StatusCode())
-	}()
+}
+
+func (h *FavoriteListHandler) DeleteFavoriteListHandle(c *fiber.Ctx) error {
 
 	listId, err := c.ParamsInt("listId")
 
@@ -301,12 +651,21 @@ func (h *FavoriteListHandler) DeleteFavoriteListHandle(c *fiber.Ctx) error {
 
 	}
 
+	expectedVersion, ok := parseIfMatch(c, listId)
+	if !ok {
+		return writePreconditionFailed(c, "DeleteFavoriteListHandle")
+	}
+
 	ctx := c.UserContext()
 
-	err = h.favoriteListService.DeleteFavoriteList(listId, authHeader, ctx)
+	err = h.favoriteListService.DeleteFavoriteList(listId, expectedVersion, authHeader, ctx)
 
 	if err != nil {
 
+		if errors.Is(err, models.ErrVersionMismatch) {
+			return writePreconditionFailed(c, "DeleteFavoriteListHandle")
+		}
+
 		logs.Error(err.Error(),
 			logs.WithHandlerName("DeleteFavoriteListHandle"),
 			logs.WithStatus(fiber.StatusBadRequest),
@@ -327,12 +686,15 @@ func (h *FavoriteListHandler) DeleteFavoriteListHandle(c *fiber.Ctx) error {
 
 }
 
-func (h *FavoriteListHandler) SetRoutes(app *fiber.App) {
-	listGroup := app.Group("/lists")
+func (h *FavoriteListHandler) SetRoutes(router fiber.Router) {
+	listGroup := router.Group("/lists")
 
 	listGroup.Get("/", h.GetUserFavoriteListsWithItemsHandle)
+	listGroup.Get("/:listId", h.GetFavoriteListHandle)
 	listGroup.Post("", h.CreateFavoriteListHandle)
 	listGroup.Put("/:listId", h.UpdateFavoriteListHandle)
+	listGroup.Patch("/:listId", h.PatchFavoriteListHandle)
+	listGroup.Put("/:listId/default", h.SetDefaultListHandle)
 	listGroup.Delete("/:listId", h.DeleteFavoriteListHandle)
 }
 
@@ -343,11 +705,29 @@ func ListGetEndpoints() []*endpoint.EndPoint {
 			endpoint.GET,
 			"/lists",
 			endpoint.WithTags("lists"),
+			endpoint.WithParams(parameter.StrParam("include", parameter.Query, parameter.WithDescription("items,counts,products (varsayılan: products)"))),
+			endpoint.WithParams(parameter.StrParam("fields", parameter.Query, parameter.WithDescription("id,name,price,stock,note,quantity,priority,variant"))),
+			endpoint.WithParams(parameter.StrParam("type", parameter.Query, parameter.WithDescription("wishlist, gift_registry, collection"))),
+			endpoint.WithParams(parameter.StrParam("visibility", parameter.Query, parameter.WithDescription("private, shared, public"))),
 			endpoint.WithParams(parameter.StrParam("Authorization", parameter.Header, parameter.WithRequired())),
+			endpoint.WithParams(parameter.StrParam("If-None-Match", parameter.Header, parameter.WithDescription("ETag eşleşirse 304 döner"))),
 			endpoint.WithSuccessfulReturns([]response.Response{response.New([]models.FavoriteListResponse{}, "200", "OK")}),
 			endpoint.WithErrors([]response.Response{response.New(models.ErorResponse{}, "404", "Bad Request")}),
 		),
 
+		endpoint.New(
+			endpoint.GET,
+			"/lists/{listId}",
+			endpoint.WithTags("lists"),
+			endpoint.WithParams(parameter.IntParam("listId", parameter.Path, parameter.WithRequired())),
+			endpoint.WithParams(parameter.StrParam("include", parameter.Query, parameter.WithDescription("products: ürünleri de getirir"))),
+			endpoint.WithParams(parameter.StrParam("fields", parameter.Query, parameter.WithDescription("id,name,price,stock,note,quantity,priority,variant"))),
+			endpoint.WithParams(parameter.StrParam("Authorization", parameter.Header, parameter.WithRequired())),
+			endpoint.WithParams(parameter.StrParam("If-None-Match", parameter.Header, parameter.WithDescription("ETag eşleşirse 304 döner"))),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New(models.FavoriteListDetail{}, "200", "OK")}),
+			endpoint.WithErrors([]response.Response{response.New(models.ErorResponse{}, "404", "Not Found")}),
+		),
+
 		endpoint.New(
 			endpoint.POST,
 			"/lists",
@@ -364,19 +744,56 @@ func ListGetEndpoints() []*endpoint.EndPoint {
 			endpoint.WithTags("lists"),
 			endpoint.WithParams(parameter.IntParam("listId", parameter.Path, parameter.WithRequired())),
 			endpoint.WithParams(parameter.StrParam("Authorization", parameter.Header, parameter.WithRequired())),
+			endpoint.WithParams(parameter.StrParam("If-Match", parameter.Header, parameter.WithDescription("Listenin ETag değeri; eşleşmezse 412 döner"))),
 			endpoint.WithBody(models.UpdateFavoriteList{}),
 			endpoint.WithSuccessfulReturns([]response.Response{response.New(models.FavoriteList{}, "200", "OK")}),
 			endpoint.WithErrors([]response.Response{response.New(models.ErorResponse{}, "404", "Bad Request")}),
 		),
+		endpoint.New(
+			endpoint.PATCH,
+			"/lists/{listId}",
+			endpoint.WithTags("lists"),
+			endpoint.WithParams(parameter.IntParam("listId", parameter.Path, parameter.WithRequired())),
+			endpoint.WithParams(parameter.StrParam("Authorization", parameter.Header, parameter.WithRequired())),
+			endpoint.WithParams(parameter.StrParam("If-Match", parameter.Header, parameter.WithDescription("Listenin ETag değeri; eşleşmezse 412 döner"))),
+			endpoint.WithBody(models.PatchFavoriteList{}),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New(models.FavoriteList{}, "200", "OK")}),
+			endpoint.WithErrors([]response.Response{response.New(models.ErorResponse{}, "404", "Not Found")}),
+		),
+
+		endpoint.New(
+			endpoint.PUT,
+			"/lists/{listId}/default",
+			endpoint.WithTags("lists"),
+			endpoint.WithParams(parameter.IntParam("listId", parameter.Path, parameter.WithRequired())),
+			endpoint.WithParams(parameter.StrParam("Authorization", parameter.Header, parameter.WithRequired())),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New(models.FavoriteList{}, "200", "OK")}),
+			endpoint.WithErrors([]response.Response{response.New(models.ErorResponse{}, "404", "Not Found")}),
+		),
+
 		endpoint.New(
 			endpoint.DELETE,
 			"/lists/{listId}",
 			endpoint.WithTags("lists"),
 			endpoint.WithParams(parameter.IntParam("listId", parameter.Path, parameter.WithRequired())),
 			endpoint.WithParams(parameter.StrParam("Authorization", parameter.Header, parameter.WithRequired())),
+			endpoint.WithParams(parameter.StrParam("If-Match", parameter.Header, parameter.WithDescription("Listenin ETag değeri; eşleşmezse 412 döner"))),
 			endpoint.WithSuccessfulReturns([]response.Response{response.New(models.SuccesResponse{}, "200", "OK")}),
 			endpoint.WithErrors([]response.Response{response.New(models.ErorResponse{}, "404", "Bad Request")}),
 		),
 	}
 
 }
+
+func writePreconditionFailed(c *fiber.Ctx, handlerName string) error {
+
+	logs.Warning(models.ErrVersionMismatch.Error(),
+		logs.WithHandlerName(handlerName),
+		logs.WithStatus(fiber.StatusPreconditionFailed),
+	)
+
+	return c.Status(fiber.StatusPreconditionFailed).JSON(models.ErorResponse{
+		Error:   "On Kosul Saglanmadi",
+		Details: models.ErrVersionMismatch.Error()},
+	)
+}
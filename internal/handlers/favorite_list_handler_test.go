@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"favorite_service/internal/models"
+	"fmt"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 
@@ -41,6 +43,73 @@ func TestFavoriteListHandler(t *testing.T) {
 
 	})
 
+	t.Run("TestGetUserFavoriteListsHandleCountsOnly", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/lists?include=counts", nil)
+
+		request.Header.Set("Authorization", "1")
+
+		resp, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var body struct {
+			SuccesData []models.FavoriteListResponse
+		}
+
+		assert.Nil(t, json.NewDecoder(resp.Body).Decode(&body))
+
+		for _, list := range body.SuccesData {
+			assert.NotNil(t, list.ItemCount)
+			assert.Nil(t, list.Items)
+		}
+
+	})
+
+	t.Run("TestGetUserFavoriteListsHandleFields", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/lists?fields=id,name", nil)
+
+		request.Header.Set("Authorization", "1")
+
+		resp, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var body struct {
+			SuccesData []map[string]interface{}
+		}
+
+		assert.Nil(t, json.NewDecoder(resp.Body).Decode(&body))
+
+		for _, list := range body.SuccesData {
+			products, _ := list["products"].([]interface{})
+			for _, product := range products {
+				assert.NotContains(t, product, "price")
+				assert.Contains(t, product, "name")
+			}
+		}
+
+	})
+
+	t.Run("TestGetUserFavoriteListsHandleInvalidInclude", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/lists?include=reviews", nil)
+
+		request.Header.Set("Authorization", "1")
+
+		resp, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	})
+
 	t.Run("TestCreateFavoriteListHandle", func(t *testing.T) {
 
 		newList := models.CreateFavoriteList{
@@ -88,6 +157,349 @@ func TestFavoriteListHandler(t *testing.T) {
 		assert.Equal(t, fiber.StatusBadRequest, response.StatusCode)
 	})
 
+	t.Run("TestGetFavoriteListHandle", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/lists/1", nil)
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+		var body struct {
+			SuccesData models.FavoriteListDetail
+		}
+
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&body))
+
+		assert.Equal(t, 1, body.SuccesData.ListId)
+
+		assert.Empty(t, body.SuccesData.Items)
+
+	})
+
+	t.Run("TestGetFavoriteListHandleIncludeProducts", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/lists/1?include=products", nil)
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+		var body struct {
+			SuccesData models.FavoriteListDetail
+		}
+
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&body))
+
+		assert.Len(t, body.SuccesData.Items, body.SuccesData.ItemCount)
+
+	})
+
+	t.Run("TestGetFavoriteListHandleSparseFields", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/lists/1?include=products&fields=id,name", nil)
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+		var body struct {
+			SuccesData struct {
+				ListId    int                      `json:"list_id"`
+				ItemCount int                      `json:"item_count"`
+				Products  []map[string]interface{} `json:"products"`
+			}
+		}
+
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&body))
+
+		assert.Equal(t, 1, body.SuccesData.ListId)
+
+		assert.Len(t, body.SuccesData.Products, body.SuccesData.ItemCount)
+
+		for _, product := range body.SuccesData.Products {
+			assert.Len(t, product, 2)
+			assert.Contains(t, product, "id")
+			assert.Contains(t, product, "name")
+		}
+
+	})
+
+	t.Run("TestGetFavoriteListHandleInvalidInclude", func(t *testing.T) {
+
+		for _, query := range []string{"include=reviews", "fields=secret"} {
+
+			request := httptest.NewRequest("GET", "/lists/1?"+query, nil)
+
+			request.Header.Set("Authorization", "1")
+
+			response, err := app.Test(request)
+
+			assert.Nil(t, err)
+
+			assert.Equal(t, fiber.StatusBadRequest, response.StatusCode)
+		}
+
+	})
+
+	t.Run("TestGetFavoriteListHandleNotFound", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/lists/999", nil)
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusNotFound, response.StatusCode)
+
+	})
+
+	t.Run("TestGetFavoriteListHandleForbidden", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/lists/2", nil)
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusForbidden, response.StatusCode)
+
+	})
+
+	t.Run("TestCreateFavoriteListHandleWithMetadata", func(t *testing.T) {
+
+		body := []byte(`{"list_name":"Düğün Listesi","description":"Hediye önerileri","visibility":"shared","list_type":"gift_registry","emoji":"🎁","cover_image_url":"https://example.com/kapak.jpg"}`)
+
+		request := httptest.NewRequest("POST", "/lists", bytes.NewReader(body))
+
+		request.Header.Set("Content-Type", "application/json")
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+	})
+
+	t.Run("TestCreateFavoriteListHandleInvalidVisibility", func(t *testing.T) {
+
+		body := []byte(`{"list_name":"Gizli Liste","visibility":"secret"}`)
+
+		request := httptest.NewRequest("POST", "/lists", bytes.NewReader(body))
+
+		request.Header.Set("Content-Type", "application/json")
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusBadRequest, response.StatusCode)
+
+	})
+
+	t.Run("TestGetUserFavoriteListsHandleFilterByType", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/lists?type=gift_registry&visibility=shared&include=counts", nil)
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+		var body struct {
+			SuccesData []models.FavoriteListResponse
+		}
+
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&body))
+
+		assert.NotEmpty(t, body.SuccesData)
+
+		for _, list := range body.SuccesData {
+			assert.Equal(t, models.ListTypeGiftRegistry, list.ListType)
+			assert.Equal(t, models.VisibilityShared, list.Visibility)
+		}
+
+	})
+
+	t.Run("TestPatchFavoriteListHandle", func(t *testing.T) {
+
+		body := []byte(`{"description":"Yeni açıklama","visibility":"public"}`)
+
+		request := httptest.NewRequest("PATCH", "/lists/1", bytes.NewReader(body))
+
+		request.Header.Set("Content-Type", "application/json")
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+		var result struct {
+			SuccesData models.FavoriteList
+		}
+
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&result))
+
+		assert.Equal(t, "Yeni açıklama", result.SuccesData.Description)
+
+		assert.Equal(t, models.VisibilityPublic, result.SuccesData.Visibility)
+
+	})
+
+	t.Run("TestGetFavoriteListHandleNotModified", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/lists/1", nil)
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+		etag := response.Header.Get(fiber.HeaderETag)
+
+		assert.NotEmpty(t, etag)
+
+		request = httptest.NewRequest("GET", "/lists/1", nil)
+
+		request.Header.Set("Authorization", "1")
+
+		request.Header.Set(fiber.HeaderIfNoneMatch, etag)
+
+		response, err = app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusNotModified, response.StatusCode)
+
+	})
+
+	t.Run("TestPatchFavoriteListHandleIfMatch", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/lists/1", nil)
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		etag := response.Header.Get(fiber.HeaderETag)
+
+		patch := func(ifMatch string) *http.Response {
+
+			request := httptest.NewRequest("PATCH", "/lists/1", bytes.NewReader([]byte(`{"emoji":"🎁"}`)))
+
+			request.Header.Set("Content-Type", "application/json")
+
+			request.Header.Set("Authorization", "1")
+
+			request.Header.Set(fiber.HeaderIfMatch, ifMatch)
+
+			response, err := app.Test(request)
+
+			assert.Nil(t, err)
+
+			return response
+		}
+
+		response = patch(etag)
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+		assert.NotEqual(t, etag, response.Header.Get(fiber.HeaderETag))
+
+		response = patch(etag)
+
+		assert.Equal(t, fiber.StatusPreconditionFailed, response.StatusCode)
+
+		response = patch(`"baska-etag"`)
+
+		assert.Equal(t, fiber.StatusPreconditionFailed, response.StatusCode)
+
+	})
+
+	t.Run("TestPatchFavoriteListHandleEmptyBody", func(t *testing.T) {
+
+		request := httptest.NewRequest("PATCH", "/lists/1", bytes.NewReader([]byte(`{}`)))
+
+		request.Header.Set("Content-Type", "application/json")
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusBadRequest, response.StatusCode)
+
+	})
+
+	t.Run("TestSetDefaultListHandle", func(t *testing.T) {
+
+		request := httptest.NewRequest("PUT", "/lists/1/default", nil)
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+		var result struct {
+			SuccesData models.FavoriteList
+		}
+
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&result))
+
+		assert.True(t, result.SuccesData.IsDefault)
+
+	})
+
+	t.Run("TestSetDefaultListHandleForbidden", func(t *testing.T) {
+
+		request := httptest.NewRequest("PUT", "/lists/2/default", nil)
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusForbidden, response.StatusCode)
+
+	})
+
 	t.Run("TestUpdateFavoriteListHandle", func(t *testing.T) {
 
 		updatedList := models.UpdateFavoriteList{
@@ -149,6 +561,42 @@ func TestFavoriteListHandler(t *testing.T) {
 		assert.Equal(t, fiber.StatusOK, response.StatusCode)
 	})
 
+	t.Run("TestDeleteFavoriteListHandleEmptyList", func(t *testing.T) {
+
+		body, err := json.Marshal(models.CreateFavoriteList{ListName: "Boş Liste"})
+
+		assert.Nil(t, err)
+
+		request := httptest.NewRequest("POST", "/lists", bytes.NewReader(body))
+
+		request.Header.Set("Content-Type", "application/json")
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+		var created struct {
+			SuccesData models.FavoriteList
+		}
+
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&created))
+
+		request = httptest.NewRequest("DELETE", fmt.Sprintf("/lists/%d", created.SuccesData.Id), nil)
+
+		request.Header.Set("Authorization", "1")
+
+		response, err = app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+	})
+
 	t.Run("TestDeleteFavoriteListHandleBadRequest", func(t *testing.T) {
 
 		request := httptest.NewRequest("DELETE", "/lists/100", nil)
@@ -0,0 +1,340 @@
+package handlers
+
+import (
+	"fmt"
+
+	"favorite_service/internal/models"
+
+	"github.com/go-swagno/swagno/components/endpoint"
+	"github.com/go-swagno/swagno/components/http/response"
+	"github.com/go-swagno/swagno/components/parameter"
+	"github.com/gofiber/fiber/v2"
+)
+
+// FavoriteV2Handler liste ve ürünleri iç içe kaynaklar olarak (/v2/lists/:listId/items/:itemId)
+// sunar. Hatalar RFC 7807 problem details, başarılı yanıtlar {"data": ...} zarfı ile döner.
+type FavoriteV2Handler struct {
+	favoriteListService favoriteListService
+	favoriteItemService favoriteItemService
+}
+
+func NewFavoriteV2Handler(favoriteListService favoriteListService, favoriteItemService favoriteItemService) *FavoriteV2Handler {
+	return &FavoriteV2Handler{
+		favoriteListService: favoriteListService,
+		favoriteItemService: favoriteItemService,
+	}
+}
+
+func (h *FavoriteV2Handler) GetListsHandle(c *fiber.Ctx) error {
+
+	token := c.Get("Authorization")
+	if token == "" {
+		return writeProblem(c, fiber.StatusUnauthorized, "Token Authorization Hatasi", "Authorization header zorunlu")
+	}
+
+	lists, err := h.favoriteListService.GetUserFavoriteListsWithItems(token, c.UserContext())
+	if err != nil {
+		return serviceProblem(c, "FavoriteV2Handler_GetLists", err)
+	}
+
+	if lists == nil {
+		lists = []models.FavoriteListResponse{}
+	}
+
+	if err := c.Status(fiber.StatusOK).JSON(models.DataResponse{Data: lists}); err != nil {
+		return err
+	}
+
+	return writeConditional(c, "")
+}
+
+func (h *FavoriteV2Handler) CreateListHandle(c *fiber.Ctx) error {
+
+	token := c.Get("Authorization")
+	if token == "" {
+		return writeProblem(c, fiber.StatusUnauthorized, "Token Authorization Hatasi", "Authorization header zorunlu")
+	}
+
+	request := models.CreateFavoriteList{}
+	if err := c.BodyParser(&request); err != nil {
+		return writeProblem(c, fiber.StatusBadRequest, "Body Parse Hatasi", err.Error())
+	}
+
+	if err := request.Validate(); err != nil {
+		return writeProblem(c, fiber.StatusUnprocessableEntity, "Validate Hatasi", err.Error())
+	}
+
+	list := models.FavoriteList{ListName: request.ListName, FavoriteListMetadata: request.FavoriteListMetadata}
+
+	if err := h.favoriteListService.CreateFavoriteList(&list, token, c.UserContext()); err != nil {
+		return serviceProblem(c, "FavoriteV2Handler_CreateList", err)
+	}
+
+	c.Location(fmt.Sprintf("/v2/lists/%d", list.Id))
+	c.Set(fiber.HeaderETag, listETag(list.Id, list.Version))
+
+	return c.Status(fiber.StatusCreated).JSON(models.DataResponse{Data: list})
+}
+
+func (h *FavoriteV2Handler) UpdateListHandle(c *fiber.Ctx) error {
+
+	listId, err := c.ParamsInt("listId")
+	if err != nil {
+		return writeProblem(c, fiber.StatusBadRequest, "List Id Hatasi", err.Error())
+	}
+
+	token := c.Get("Authorization")
+	if token == "" {
+		return writeProblem(c, fiber.StatusUnauthorized, "Token Authorization Hatasi", "Authorization header zorunlu")
+	}
+
+	expectedVersion, ok := parseIfMatch(c, listId)
+	if !ok {
+		return serviceProblem(c, "FavoriteV2Handler_UpdateList", models.ErrVersionMismatch)
+	}
+
+	request := models.UpdateFavoriteList{}
+	if err := c.BodyParser(&request); err != nil {
+		return writeProblem(c, fiber.StatusBadRequest, "Body Parse Hatasi", err.Error())
+	}
+
+	if err := request.Validate(); err != nil {
+		return writeProblem(c, fiber.StatusUnprocessableEntity, "Validate Hatasi", err.Error())
+	}
+
+	list, err := h.favoriteListService.UpdateFavoriteList(listId, request, expectedVersion, token, c.UserContext())
+	if err != nil {
+		return serviceProblem(c, "FavoriteV2Handler_UpdateList", err)
+	}
+
+	c.Set(fiber.HeaderETag, listETag(list.Id, list.Version))
+
+	return c.Status(fiber.StatusOK).JSON(models.DataResponse{Data: list})
+}
+
+func (h *FavoriteV2Handler) DeleteListHandle(c *fiber.Ctx) error {
+
+	listId, err := c.ParamsInt("listId")
+	if err != nil {
+		return writeProblem(c, fiber.StatusBadRequest, "List Id Hatasi", err.Error())
+	}
+
+	token := c.Get("Authorization")
+	if token == "" {
+		return writeProblem(c, fiber.StatusUnauthorized, "Token Authorization Hatasi", "Authorization header zorunlu")
+	}
+
+	expectedVersion, ok := parseIfMatch(c, listId)
+	if !ok {
+		return serviceProblem(c, "FavoriteV2Handler_DeleteList", models.ErrVersionMismatch)
+	}
+
+	if err := h.favoriteListService.DeleteFavoriteList(listId, expectedVersion, token, c.UserContext()); err != nil {
+		return serviceProblem(c, "FavoriteV2Handler_DeleteList", err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *FavoriteV2Handler) GetItemsHandle(c *fiber.Ctx) error {
+
+	listId, err := c.ParamsInt("listId")
+	if err != nil {
+		return writeProblem(c, fiber.StatusBadRequest, "List Id Hatasi", err.Error())
+	}
+
+	token := c.Get("Authorization")
+	if token == "" {
+		return writeProblem(c, fiber.StatusUnauthorized, "Token Authorization Hatasi", "Authorization header zorunlu")
+	}
+
+	products, err := h.favoriteItemService.GetFavoriteItem(listId, token, c.UserContext())
+	if err != nil {
+		return serviceProblem(c, "FavoriteV2Handler_GetItems", err)
+	}
+
+	if products == nil {
+		products = []models.FavoriteProduct{}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(models.DataResponse{Data: products})
+}
+
+func (h *FavoriteV2Handler) AddItemHandle(c *fiber.Ctx) error {
+
+	listId, err := c.ParamsInt("listId")
+	if err != nil {
+		return writeProblem(c, fiber.StatusBadRequest, "List Id Hatasi", err.Error())
+	}
+
+	token := c.Get("Authorization")
+	if token == "" {
+		return writeProblem(c, fiber.StatusUnauthorized, "Token Authorization Hatasi", "Authorization header zorunlu")
+	}
+
+	request := models.AddListItem{}
+	if err := c.BodyParser(&request); err != nil {
+		return writeProblem(c, fiber.StatusBadRequest, "Body Parse Hatasi", err.Error())
+	}
+
+	if err := request.Validate(); err != nil {
+		return writeProblem(c, fiber.StatusUnprocessableEntity, "Validate Hatasi", err.Error())
+	}
+
+	item, err := h.favoriteItemService.CreateFavoriteItem(models.CreateFavoriteItem{ItemId: request.ItemId, ListId: listId}, token, c.UserContext())
+	if err != nil {
+		return serviceProblem(c, "FavoriteV2Handler_AddItem", err)
+	}
+
+	c.Location(fmt.Sprintf("/v2/lists/%d/items/%d", item.ListId, item.ItemId))
+
+	return c.Status(fiber.StatusCreated).JSON(models.DataResponse{Data: item})
+}
+
+func (h *FavoriteV2Handler) RemoveItemHandle(c *fiber.Ctx) error {
+
+	listId, err := c.ParamsInt("listId")
+	if err != nil {
+		return writeProblem(c, fiber.StatusBadRequest, "List Id Hatasi", err.Error())
+	}
+
+	itemId, err := c.ParamsInt("itemId")
+	if err != nil {
+		return writeProblem(c, fiber.StatusBadRequest, "Item Id Hatasi", err.Error())
+	}
+
+	token := c.Get("Authorization")
+	if token == "" {
+		return writeProblem(c, fiber.StatusUnauthorized, "Token Authorization Hatasi", "Authorization header zorunlu")
+	}
+
+	if err := h.favoriteItemService.DeleteFavoriteItem(listId, itemId, token, c.UserContext()); err != nil {
+		return serviceProblem(c, "FavoriteV2Handler_RemoveItem", err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *FavoriteV2Handler) ContainsHandle(c *fiber.Ctx) error {
+
+	productIds, err := parseIdList(c.Query("productIds"), maxContainsProductIds)
+	if err != nil {
+		return writeProblem(c, fiber.StatusBadRequest, "Query Parametre Hatasi", err.Error())
+	}
+
+	token := c.Get("Authorization")
+	if token == "" {
+		return writeProblem(c, fiber.StatusUnauthorized, "Token Authorization Hatasi", "Authorization header zorunlu")
+	}
+
+	contains, err := h.favoriteItemService.ContainsFavoriteItems(productIds, token, c.UserContext())
+	if err != nil {
+		return serviceProblem(c, "FavoriteV2Handler_Contains", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(models.DataResponse{Data: contains})
+}
+
+func (h *FavoriteV2Handler) SetRoutes(router fiber.Router) {
+
+	listGroup := router.Group("/lists")
+
+	listGroup.Get("/", h.GetListsHandle)
+	listGroup.Post("", h.CreateListHandle)
+	listGroup.Put("/:listId", h.UpdateListHandle)
+	listGroup.Delete("/:listId", h.DeleteListHandle)
+	listGroup.Get("/:listId/items", h.GetItemsHandle)
+	listGroup.Post("/:listId/items", h.AddItemHandle)
+	listGroup.Delete("/:listId/items/:itemId", h.RemoveItemHandle)
+
+	favoriteGroup := router.Group("/favorites")
+
+	favoriteGroup.Get("/contains", h.ContainsHandle)
+
+}
+
+func V2GetEndpoints() []*endpoint.EndPoint {
+
+	authorization := parameter.StrParam("Authorization", parameter.Header, parameter.WithRequired())
+	listId := parameter.IntParam("listId", parameter.Path, parameter.WithRequired())
+	ifMatch := parameter.StrParam("If-Match", parameter.Header, parameter.WithDescription("Listenin ETag değeri; eşleşmezse 412 döner"))
+	ifNoneMatch := parameter.StrParam("If-None-Match", parameter.Header, parameter.WithDescription("ETag eşleşirse 304 döner"))
+	problem := []response.Response{response.New(models.Problem{}, "400", "Bad Request")}
+
+	return []*endpoint.EndPoint{
+		endpoint.New(
+			endpoint.GET,
+			"/v2/lists",
+			endpoint.WithTags("v2"),
+			endpoint.WithParams(authorization, ifNoneMatch),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New(models.DataResponse{Data: []models.FavoriteListResponse{}}, "200", "OK")}),
+			endpoint.WithErrors(problem),
+		),
+
+		endpoint.New(
+			endpoint.POST,
+			"/v2/lists",
+			endpoint.WithTags("v2"),
+			endpoint.WithParams(authorization),
+			endpoint.WithBody(models.CreateFavoriteList{}),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New(models.DataResponse{Data: models.FavoriteList{}}, "201", "Created")}),
+			endpoint.WithErrors(problem),
+		),
+
+		endpoint.New(
+			endpoint.PUT,
+			"/v2/lists/{listId}",
+			endpoint.WithTags("v2"),
+			endpoint.WithParams(listId, authorization, ifMatch),
+			endpoint.WithBody(models.UpdateFavoriteList{}),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New(models.DataResponse{Data: models.FavoriteList{}}, "200", "OK")}),
+			endpoint.WithErrors(problem),
+		),
+
+		endpoint.New(
+			endpoint.DELETE,
+			"/v2/lists/{listId}",
+			endpoint.WithTags("v2"),
+			endpoint.WithParams(listId, authorization, ifMatch),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New(nil, "204", "No Content")}),
+			endpoint.WithErrors(problem),
+		),
+
+		endpoint.New(
+			endpoint.GET,
+			"/v2/lists/{listId}/items",
+			endpoint.WithTags("v2"),
+			endpoint.WithParams(listId, authorization),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New(models.DataResponse{Data: []models.FavoriteProduct{}}, "200", "OK")}),
+			endpoint.WithErrors(problem),
+		),
+
+		endpoint.New(
+			endpoint.POST,
+			"/v2/lists/{listId}/items",
+			endpoint.WithTags("v2"),
+			endpoint.WithParams(listId, authorization),
+			endpoint.WithBody(models.AddListItem{}),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New(models.DataResponse{Data: models.FavoriteItem{}}, "201", "Created")}),
+			endpoint.WithErrors(problem),
+		),
+
+		endpoint.New(
+			endpoint.DELETE,
+			"/v2/lists/{listId}/items/{itemId}",
+			endpoint.WithTags("v2"),
+			endpoint.WithParams(listId, parameter.IntParam("itemId", parameter.Path, parameter.WithRequired()), authorization),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New(nil, "204", "No Content")}),
+			endpoint.WithErrors(problem),
+		),
+
+		endpoint.New(
+			endpoint.GET,
+			"/v2/favorites/contains",
+			endpoint.WithTags("v2"),
+			endpoint.WithParams(parameter.StrParam("productIds", parameter.Query, parameter.WithRequired()), authorization),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New(models.DataResponse{Data: []models.FavoriteContainsResponse{}}, "200", "OK")}),
+			endpoint.WithErrors(problem),
+		),
+	}
+}
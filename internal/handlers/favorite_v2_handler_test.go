@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"favorite_service/internal/models"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFavoriteV2Handler(t *testing.T) {
+
+	var createdList models.FavoriteList
+
+	t.Run("TestV1RouteDeprecationHeaders", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/v1/lists", nil)
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+		assert.Equal(t, "true", response.Header.Get("Deprecation"))
+
+		assert.NotEmpty(t, response.Header.Get("Sunset"))
+
+		assert.Contains(t, response.Header.Get(fiber.HeaderLink), "/v2")
+
+	})
+
+	t.Run("TestUnversionedRouteHasNoDeprecationHeader", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/lists", nil)
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Empty(t, response.Header.Get("Deprecation"))
+
+	})
+
+	t.Run("TestV2CreateListHandle", func(t *testing.T) {
+
+		body, err := json.Marshal(models.CreateFavoriteList{ListName: "V2 Liste"})
+
+		assert.Nil(t, err)
+
+		request := httptest.NewRequest("POST", "/v2/lists", bytes.NewReader(body))
+
+		request.Header.Set("Content-Type", "application/json")
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusCreated, response.StatusCode)
+
+		var envelope struct {
+			Data models.FavoriteList `json:"data"`
+		}
+
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&envelope))
+
+		createdList = envelope.Data
+
+		assert.Equal(t, fmt.Sprintf("/v2/lists/%d", createdList.Id), response.Header.Get(fiber.HeaderLocation))
+
+	})
+
+	t.Run("TestV2AddItemHandle", func(t *testing.T) {
+
+		body, err := json.Marshal(models.AddListItem{ItemId: 1})
+
+		assert.Nil(t, err)
+
+		request := httptest.NewRequest("POST", fmt.Sprintf("/v2/lists/%d/items", createdList.Id), bytes.NewReader(body))
+
+		request.Header.Set("Content-Type", "application/json")
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusCreated, response.StatusCode)
+
+		assert.Equal(t, fmt.Sprintf("/v2/lists/%d/items/1", createdList.Id), response.Header.Get(fiber.HeaderLocation))
+
+	})
+
+	t.Run("TestV2GetItemsHandle", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", fmt.Sprintf("/v2/lists/%d/items", createdList.Id), nil)
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+		var envelope struct {
+			Data []models.FavoriteProduct `json:"data"`
+		}
+
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&envelope))
+
+		assert.Len(t, envelope.Data, 1)
+
+	})
+
+	t.Run("TestV2RemoveItemHandle", func(t *testing.T) {
+
+		request := httptest.NewRequest("DELETE", fmt.Sprintf("/v2/lists/%d/items/1", createdList.Id), nil)
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusNoContent, response.StatusCode)
+
+	})
+
+	t.Run("TestV2ProblemDetails", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/v2/lists", nil)
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusUnauthorized, response.StatusCode)
+
+		assert.Equal(t, "application/problem+json", response.Header.Get(fiber.HeaderContentType))
+
+		var problem models.Problem
+
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&problem))
+
+		assert.Equal(t, fiber.StatusUnauthorized, problem.Status)
+
+	})
+
+	t.Run("TestV2DeleteListHandle", func(t *testing.T) {
+
+		getItems := func() *http.Response {
+
+			request := httptest.NewRequest("GET", fmt.Sprintf("/v2/lists/%d/items", createdList.Id), nil)
+
+			request.Header.Set("Authorization", "1")
+
+			response, err := app.Test(request)
+
+			assert.Nil(t, err)
+
+			return response
+		}
+
+		// TestV2RemoveItemHandle listenin tek ürününü çıkardı; boş liste de silinebilmelidir.
+		response := getItems()
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+		var envelope struct {
+			Data []models.FavoriteProduct `json:"data"`
+		}
+
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&envelope))
+
+		assert.Empty(t, envelope.Data)
+
+		request := httptest.NewRequest("DELETE", fmt.Sprintf("/v2/lists/%d", createdList.Id), nil)
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusNoContent, response.StatusCode)
+
+		assert.Equal(t, fiber.StatusNotFound, getItems().StatusCode)
+
+	})
+
+}
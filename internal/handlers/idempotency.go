@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"favorite_service/internal/models"
+	"favorite_service/logs"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	idempotencyKeyHeader      = "Idempotency-Key"
+	idempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	idempotencyHandlerName    = "Idempotency"
+	idempotencyProblemVersion = "/v2"
+)
+
+type idempotencyService interface {
+	Begin(ctx context.Context, scope string, key string, fingerprint string) (*models.IdempotencyRecord, error)
+	Complete(ctx context.Context, scope string, key string, status int, contentType string, body []byte) error
+	Release(ctx context.Context, scope string, key string) error
+}
+
+// Idempotency, Idempotency-Key header'ı taşıyan yazma isteklerinin yanıtını saklar ve aynı
+// anahtarla tekrar gelen istekte handler'ı çalıştırmadan saklanan yanıtı döner. Anahtarlar
+// RateLimit'in doğrulayıp c.Locals'a eklediği kullanıcının id'si ile ayrılır; böylece farklı
+// kullanıcıların anahtarları çakışmaz, aynı kullanıcı token yenilense de aynı anahtarla tekrar
+// deneyebilir. Bu yüzden RateLimit'ten sonra kaydedilmelidir. Doğrulanmış kullanıcı yoksa
+// istek saklanmadan handler'a bırakılır. 5xx ile biten istekler saklanmaz, istemci aynı anahtarla tekrar deneyebilir.
+func Idempotency(service idempotencyService) fiber.Handler {
+
+	return func(c *fiber.Ctx) error {
+
+		key := c.Get(idempotencyKeyHeader)
+		token := c.Get("Authorization")
+
+		if key == "" || token == "" || !isWriteMethod(c.Method()) {
+			return c.Next()
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			return idempotencyError(c, fiber.StatusBadRequest, "Gecersiz Idempotency-Key", "Idempotency-Key en fazla 255 karakter olabilir")
+		}
+
+		user, ok := VerifiedUser(c)
+		if !ok {
+			return c.Next()
+		}
+
+		scope := strconv.Itoa(user.ID)
+		fingerprint := requestFingerprint(c)
+
+		record, err := service.Begin(c.UserContext(), scope, key, fingerprint)
+
+		switch {
+		case errors.Is(err, models.ErrIdempotencyKeyReused):
+			return idempotencyError(c, fiber.StatusUnprocessableEntity, "Idempotency-Key Tekrar Kullanildi", err.Error())
+		case errors.Is(err, models.ErrIdempotencyInProgress):
+			c.Set(fiber.HeaderRetryAfter, "1")
+			return idempotencyError(c, fiber.StatusConflict, "Istek Isleniyor", err.Error())
+		case err != nil:
+			logs.Error(err.Error(), logs.WithHandlerName(idempotencyHandlerName), logs.WithStatus(fiber.StatusInternalServerError))
+			return idempotencyError(c, fiber.StatusInternalServerError, "Servis Hatasi", "Idempotency kaydi okunamadi")
+		}
+
+		if record != nil {
+			return replayIdempotentResponse(c, record)
+		}
+
+		handlerErr := c.Next()
+
+		status := c.Response().StatusCode()
+
+		if handlerErr != nil || status >= fiber.StatusInternalServerError {
+
+			if err := service.Release(c.UserContext(), scope, key); err != nil {
+				logs.Error(err.Error(), logs.WithHandlerName(idempotencyHandlerName))
+			}
+
+			return handlerErr
+		}
+
+		body := append([]byte(nil), c.Response().Body()...)
+
+		if err := service.Complete(c.UserContext(), scope, key, status, string(c.Response().Header.ContentType()), body); err != nil {
+			logs.Error(err.Error(), logs.WithHandlerName(idempotencyHandlerName))
+		}
+
+		return nil
+	}
+}
+
+func replayIdempotentResponse(c *fiber.Ctx, record *models.IdempotencyRecord) error {
+
+	c.Set(idempotentReplayedHeader, "true")
+
+	if record.ContentType != nil && *record.ContentType != "" {
+		c.Set(fiber.HeaderContentType, *record.ContentType)
+	}
+
+	status := fiber.StatusOK
+	if record.ResponseStatus != nil {
+		status = *record.ResponseStatus
+	}
+
+	return c.Status(status).Send(record.ResponseBody)
+}
+
+func idempotencyError(c *fiber.Ctx, status int, title string, detail string) error {
+
+	if strings.HasPrefix(c.Path(), idempotencyProblemVersion) {
+		return writeProblem(c, status, title, detail)
+	}
+
+	return c.Status(status).JSON(models.ErorResponse{
+		Error:   title,
+		Details: detail},
+	)
+}
+
+// requestFingerprint method, path, query, koşullu istek header'ları ve gövdeden oluşur; aynı anahtarla
+// farklı bir endpoint'e, farklı bir versiyon koşuluyla ya da farklı gövdeyle gelen istek tekrar sayılmaz.
+func requestFingerprint(c *fiber.Ctx) string {
+
+	hash := sha256.New()
+
+	hash.Write([]byte(c.Method()))
+	hash.Write([]byte{0})
+	hash.Write([]byte(c.Path()))
+	hash.Write([]byte{0})
+	hash.Write(c.Request().URI().QueryString())
+	hash.Write([]byte{0})
+	hash.Write([]byte(c.Get(fiber.HeaderIfMatch)))
+	hash.Write([]byte{0})
+	hash.Write([]byte(c.Get(fiber.HeaderIfNoneMatch)))
+	hash.Write([]byte{0})
+	hash.Write(c.Body())
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func sha256Hex(data []byte) string {
+
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+func isWriteMethod(method string) bool {
+
+	switch method {
+	case fiber.MethodPost, fiber.MethodPut, fiber.MethodPatch, fiber.MethodDelete:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"favorite_service/internal/models"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyMiddleware(t *testing.T) {
+
+	send := func(key string, list models.CreateFavoriteList) *http.Response {
+
+		body, err := json.Marshal(list)
+
+		assert.Nil(t, err)
+
+		request := httptest.NewRequest("POST", "/lists", bytes.NewReader(body))
+
+		request.Header.Set("Content-Type", "application/json")
+
+		request.Header.Set("Authorization", "1")
+
+		request.Header.Set(idempotencyKeyHeader, key)
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		return response
+	}
+
+	t.Run("TestIdempotencyReplaysResponse", func(t *testing.T) {
+
+		list := models.CreateFavoriteList{ListName: "Tekrar Deneme"}
+
+		first := send("create-list-1", list)
+
+		assert.Equal(t, fiber.StatusOK, first.StatusCode)
+
+		firstBody, err := io.ReadAll(first.Body)
+
+		assert.Nil(t, err)
+
+		second := send("create-list-1", list)
+
+		assert.Equal(t, fiber.StatusOK, second.StatusCode)
+
+		assert.Equal(t, "true", second.Header.Get(idempotentReplayedHeader))
+
+		secondBody, err := io.ReadAll(second.Body)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, firstBody, secondBody)
+
+	})
+
+	t.Run("TestIdempotencyKeyReusedWithDifferentBody", func(t *testing.T) {
+
+		response := send("create-list-1", models.CreateFavoriteList{ListName: "Baska Liste"})
+
+		assert.Equal(t, fiber.StatusUnprocessableEntity, response.StatusCode)
+
+	})
+
+	t.Run("TestIdempotencyKeyTooLong", func(t *testing.T) {
+
+		response := send(string(bytes.Repeat([]byte("a"), maxIdempotencyKeyLength+1)), models.CreateFavoriteList{ListName: "Uzun"})
+
+		assert.Equal(t, fiber.StatusBadRequest, response.StatusCode)
+
+	})
+
+	t.Run("TestIdempotencyReplaysClientErrors", func(t *testing.T) {
+
+		response := send("create-list-2", models.CreateFavoriteList{ListName: ""})
+
+		assert.Equal(t, fiber.StatusBadRequest, response.StatusCode)
+
+		replayed := send("create-list-2", models.CreateFavoriteList{ListName: ""})
+
+		assert.Equal(t, fiber.StatusBadRequest, replayed.StatusCode)
+
+		assert.Equal(t, "true", replayed.Header.Get(idempotentReplayedHeader))
+
+	})
+
+	t.Run("TestIdempotencyKeyReusedWithDifferentIfMatch", func(t *testing.T) {
+
+		body, err := json.Marshal(models.CreateFavoriteList{ListName: "Tekrar Deneme"})
+
+		assert.Nil(t, err)
+
+		request := httptest.NewRequest("POST", "/lists", bytes.NewReader(body))
+
+		request.Header.Set("Content-Type", "application/json")
+
+		request.Header.Set("Authorization", "1")
+
+		request.Header.Set(idempotencyKeyHeader, "create-list-1")
+
+		request.Header.Set(fiber.HeaderIfMatch, `"3"`)
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusUnprocessableEntity, response.StatusCode)
+
+	})
+
+	t.Run("TestIdempotencySkipsUnverifiedToken", func(t *testing.T) {
+
+		for i := 0; i < 2; i++ {
+
+			request := httptest.NewRequest("POST", "/favorites/7", nil)
+
+			request.Header.Set("Authorization", "99")
+
+			request.Header.Set(idempotencyKeyHeader, "create-list-3")
+
+			response, err := app.Test(request)
+
+			assert.Nil(t, err)
+
+			assert.Equal(t, fiber.StatusUnauthorized, response.StatusCode)
+
+			assert.Empty(t, response.Header.Get(idempotentReplayedHeader))
+		}
+
+	})
+
+}
@@ -0,0 +1,322 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"favorite_service/internal/models"
+	"favorite_service/logs"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/go-swagno/swagno/components/endpoint"
+	"github.com/go-swagno/swagno/components/http/response"
+	"github.com/go-swagno/swagno/components/parameter"
+	"github.com/gofiber/fiber/v2"
+)
+
+type importService interface {
+	ImportFavorites(rows []models.ImportRow, dryRun bool, token string, ctx context.Context) (models.ImportReport, error)
+	ImportFromAccount(sourceToken string, dryRun bool, token string, ctx context.Context) (models.ImportReport, error)
+}
+
+type ImportHandler struct {
+	importService importService
+}
+
+func NewImportHandler(importService importService) *ImportHandler {
+	return &ImportHandler{
+		importService: importService,
+	}
+}
+
+func (h *ImportHandler) ImportFavoritesHandle(c *fiber.Ctx) error {
+
+	authHeader := c.Get("Authorization")
+	if authHeader == "" {
+
+		logs.Warning("Token Authorization Hatasi",
+			logs.WithHandlerName("ImportHandler_ImportFavorites"),
+			logs.WithStatus(fiber.StatusUnauthorized),
+		)
+
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErorResponse{
+			Error:   "Token Authorization Hatasi",
+			Details: "Token"},
+		)
+	}
+
+	rows, err := parseImportBody(c)
+
+	if err == nil && len(rows) == 0 {
+		err = errors.New("en az bir satır gönderilmeli")
+	}
+
+	if err == nil && len(rows) > models.MaxImportRows {
+		err = fmt.Errorf("en fazla %d satır içe aktarılabilir", models.MaxImportRows)
+	}
+
+	if err != nil {
+
+		logs.Warning(err.Error(),
+			logs.WithHandlerName("ImportHandler_ImportFavorites"),
+			logs.WithStatus(fiber.StatusBadRequest),
+		)
+
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Body Parse Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	dryRun := c.QueryBool("dryRun")
+
+	ctx := c.UserContext()
+
+	report, err := h.importService.ImportFavorites(rows, dryRun, authHeader, ctx)
+
+	return writeImportResult(c, "ImportHandler_ImportFavorites", report, dryRun, err)
+
+}
+
+// ImportFromAccountHandle gövdede token'ı verilen başka bir hesabın listelerini token sahibi hesaba aktarır.
+func (h *ImportHandler) ImportFromAccountHandle(c *fiber.Ctx) error {
+
+	authHeader := c.Get("Authorization")
+	if authHeader == "" {
+
+		logs.Warning("Token Authorization Hatasi",
+			logs.WithHandlerName("ImportHandler_ImportFromAccount"),
+			logs.WithStatus(fiber.StatusUnauthorized),
+		)
+
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErorResponse{
+			Error:   "Token Authorization Hatasi",
+			Details: "Token"},
+		)
+	}
+
+	request := models.AccountImportRequest{}
+
+	err := c.BodyParser(&request)
+
+	if err == nil && strings.TrimSpace(request.SourceToken) == "" {
+		err = errors.New("source_token zorunlu")
+	}
+
+	if err != nil {
+
+		logs.Warning(err.Error(),
+			logs.WithHandlerName("ImportHandler_ImportFromAccount"),
+			logs.WithStatus(fiber.StatusBadRequest),
+		)
+
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Body Parse Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	dryRun := c.QueryBool("dryRun")
+
+	ctx := c.UserContext()
+
+	report, err := h.importService.ImportFromAccount(request.SourceToken, dryRun, authHeader, ctx)
+
+	return writeImportResult(c, "ImportHandler_ImportFromAccount", report, dryRun, err)
+
+}
+
+// writeImportResult içe aktarım sonucunu yazar: dry run 200, commit 201 döner; geçersiz satırlar
+// raporla birlikte 422, product servisine ulaşılamaması 503 olarak döner.
+func writeImportResult(c *fiber.Ctx, handlerName string, report models.ImportReport, dryRun bool, err error) error {
+
+	if err != nil {
+
+		var quotaErr *models.QuotaExceededError
+		if errors.As(err, &quotaErr) {
+			return writeQuotaExceeded(c, handlerName, quotaErr)
+		}
+
+		if errors.Is(err, models.ErrImportHasInvalidRows) {
+
+			logs.Warning(err.Error(),
+				logs.WithHandlerName(handlerName),
+				logs.WithStatus(fiber.StatusUnprocessableEntity),
+			)
+
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(models.ImportErrorResponse{
+				Error:   "Geçersiz Satırlar",
+				Details: err.Error(),
+				Report:  report,
+			})
+		}
+
+		status, message := fiber.StatusInternalServerError, "Servis Hatasi"
+
+		switch {
+		case errors.Is(err, models.ErrUserUnauthorized), errors.Is(err, models.ErrUserNotFound):
+			status, message = fiber.StatusUnauthorized, "Token Authorization Hatasi"
+		case errors.Is(err, models.ErrImportTooManyProducts), errors.Is(err, models.ErrImportTooManyRows), errors.Is(err, models.ErrImportSameAccount):
+			status, message = fiber.StatusBadRequest, "Body Parse Hatasi"
+		case errors.Is(err, models.ErrRecordNotFound):
+			status, message = fiber.StatusNotFound, "Liste Bulunamadi"
+		case errors.Is(err, models.ErrProductServiceUnavailable), errors.Is(err, context.DeadlineExceeded):
+			status, message = fiber.StatusServiceUnavailable, "Ürün Servisi Hatasi"
+			c.Set(fiber.HeaderRetryAfter, "5")
+		}
+
+		logs.Error(err.Error(),
+			logs.WithHandlerName(handlerName),
+			logs.WithStatus(status),
+		)
+
+		return c.Status(status).JSON(models.ErorResponse{
+			Error:   message,
+			Details: err.Error()},
+		)
+	}
+
+	if dryRun {
+		return c.Status(fiber.StatusOK).JSON(models.SuccesResponse{SuccesData: report})
+	}
+
+	logs.Info(fmt.Sprintf("İçe aktarım tamamlandı: %d liste, %d ürün", report.ListsCreated, report.ItemsCreated),
+		logs.WithHandlerName(handlerName),
+		logs.WithStatus(fiber.StatusCreated),
+	)
+
+	return c.Status(fiber.StatusCreated).JSON(models.SuccesResponse{SuccesData: report})
+}
+
+// parseImportBody Content-Type text/csv ise CSV, değilse JSON gövdeyi satırlara çevirir.
+func parseImportBody(c *fiber.Ctx) ([]models.ImportRow, error) {
+
+	if c.Is("csv") {
+		return parseImportCSV(bytes.NewReader(c.Body()))
+	}
+
+	request := models.ImportRequest{}
+
+	if err := c.BodyParser(&request); err != nil {
+		return nil, err
+	}
+
+	return request.Rows(), nil
+}
+
+// parseImportCSV başlık satırında list_name ve item_id sütunlarını arar, diğer sütunları yok sayar.
+// Bu sayede /me/export ile alınan CSV dosyası da doğrudan içe aktarılabilir. Boş item_id
+// yalnızca listenin oluşturulacağı anlamına gelir.
+func parseImportCSV(r io.Reader) ([]models.ImportRow, error) {
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	nameIdx, itemIdx := -1, -1
+
+	for i, column := range header {
+		switch strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff"))) {
+		case "list_name":
+			nameIdx = i
+		case "item_id":
+			itemIdx = i
+		}
+	}
+
+	if nameIdx == -1 || itemIdx == -1 {
+		return nil, errors.New("CSV başlığında list_name ve item_id sütunları olmalı")
+	}
+
+	var rows []models.ImportRow
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(rows) == models.MaxImportRows {
+			return nil, fmt.Errorf("en fazla %d satır içe aktarılabilir", models.MaxImportRows)
+		}
+
+		row := models.ImportRow{Row: len(rows) + 1}
+
+		if nameIdx < len(record) {
+			row.ListName = record[nameIdx]
+		}
+
+		if itemIdx < len(record) {
+			if value := strings.TrimSpace(record[itemIdx]); value != "" {
+				if row.ItemId, err = strconv.Atoi(value); err != nil {
+					return nil, fmt.Errorf("%d. satır: item_id sayı olmalı: %q", row.Row, value)
+				}
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func (h *ImportHandler) SetRoutes(router fiber.Router) {
+
+	router.Post("/lists/import", h.ImportFavoritesHandle)
+	router.Post("/lists/import/account", h.ImportFromAccountHandle)
+
+}
+
+func ImportGetEndpoints() []*endpoint.EndPoint {
+	return []*endpoint.EndPoint{
+		endpoint.New(
+			endpoint.POST,
+			"/lists/import",
+			endpoint.WithTags("lists"),
+			endpoint.WithParams(
+				parameter.StrParam("Authorization", parameter.Header, parameter.WithRequired()),
+				parameter.StrParam("Content-Type", parameter.Header, parameter.WithDescription("application/json veya text/csv (list_name,item_id sütunları)")),
+				parameter.BoolParam("dryRun", parameter.Query, parameter.WithDescription("true ise hiçbir kayıt oluşturulmaz, yalnızca rapor döner")),
+			),
+			endpoint.WithBody(models.ImportRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New(models.ImportReport{}, "201", "Created")}),
+			endpoint.WithErrors([]response.Response{
+				response.New(models.ErorResponse{}, "400", "Bad Request"),
+				response.New(models.ImportErrorResponse{}, "422", "Unprocessable Entity"),
+				response.New(models.ErorResponse{}, "503", "Service Unavailable"),
+			}),
+		),
+		endpoint.New(
+			endpoint.POST,
+			"/lists/import/account",
+			endpoint.WithTags("lists"),
+			endpoint.WithParams(
+				parameter.StrParam("Authorization", parameter.Header, parameter.WithRequired()),
+				parameter.BoolParam("dryRun", parameter.Query, parameter.WithDescription("true ise hiçbir kayıt oluşturulmaz, yalnızca rapor döner")),
+			),
+			endpoint.WithBody(models.AccountImportRequest{}),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New(models.ImportReport{}, "201", "Created")}),
+			endpoint.WithErrors([]response.Response{
+				response.New(models.ErorResponse{}, "400", "Bad Request"),
+				response.New(models.ErorResponse{}, "404", "Not Found"),
+				response.New(models.ImportErrorResponse{}, "422", "Unprocessable Entity"),
+				response.New(models.ErorResponse{}, "503", "Service Unavailable"),
+			}),
+		),
+	}
+}
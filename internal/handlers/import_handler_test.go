@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"favorite_service/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportHandler(t *testing.T) {
+
+	importJSON := func(t *testing.T, path string, request models.ImportRequest) *http.Response {
+
+		body, err := json.Marshal(request)
+
+		assert.Nil(t, err)
+
+		httpRequest := httptest.NewRequest("POST", path, bytes.NewReader(body))
+
+		httpRequest.Header.Set("Content-Type", "application/json")
+		httpRequest.Header.Set("Authorization", "1")
+
+		response, err := app.Test(httpRequest)
+
+		assert.Nil(t, err)
+
+		return response
+	}
+
+	var importedListId int
+
+	t.Run("TestImportFavoritesHandleDryRun", func(t *testing.T) {
+
+		response := importJSON(t, "/lists/import?dryRun=true", models.ImportRequest{
+			Lists: []models.ImportList{{ListName: "İçe Aktarım", ItemIds: []int{1, 3, 3, 99}}},
+		})
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+		var body struct {
+			SuccesData models.ImportReport
+		}
+
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&body))
+
+		report := body.SuccesData
+
+		assert.True(t, report.DryRun)
+		assert.False(t, report.Committed)
+		assert.Equal(t, 1, report.ListsCreated)
+		assert.Equal(t, 2, report.ItemsCreated)
+		assert.Equal(t, 1, report.Duplicates)
+		assert.Equal(t, 1, report.Invalid)
+
+		assert.Len(t, report.Rows, 4)
+		assert.Equal(t, models.ImportRowStatusDuplicate, report.Rows[2].Status)
+		assert.Equal(t, models.ImportRowStatusInvalid, report.Rows[3].Status)
+		assert.NotEmpty(t, report.Rows[3].Error)
+
+	})
+
+	t.Run("TestImportFavoritesHandleInvalidRows", func(t *testing.T) {
+
+		response := importJSON(t, "/lists/import", models.ImportRequest{
+			Lists: []models.ImportList{{ListName: "İçe Aktarım", ItemIds: []int{1, 99}}},
+		})
+
+		assert.Equal(t, fiber.StatusUnprocessableEntity, response.StatusCode)
+
+		var body models.ImportErrorResponse
+
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&body))
+
+		assert.False(t, body.Report.Committed)
+		assert.Equal(t, 1, body.Report.Invalid)
+
+	})
+
+	t.Run("TestImportFavoritesHandleProductServiceUnavailable", func(t *testing.T) {
+
+		response := importJSON(t, "/lists/import?dryRun=true", models.ImportRequest{
+			Lists: []models.ImportList{{ListName: "İçe Aktarım", ItemIds: []int{1, 98}}},
+		})
+
+		assert.Equal(t, fiber.StatusServiceUnavailable, response.StatusCode)
+
+	})
+
+	t.Run("TestImportFavoritesHandleTooManyProducts", func(t *testing.T) {
+
+		itemIds := make([]int, 0, models.MaxImportProducts+1)
+		for i := 1000; len(itemIds) <= models.MaxImportProducts; i++ {
+			itemIds = append(itemIds, i)
+		}
+
+		response := importJSON(t, "/lists/import?dryRun=true", models.ImportRequest{
+			Lists: []models.ImportList{{ListName: "Çok Ürün", ItemIds: itemIds}},
+		})
+
+		assert.Equal(t, fiber.StatusBadRequest, response.StatusCode)
+
+	})
+
+	t.Run("TestImportFavoritesHandleCSV", func(t *testing.T) {
+
+		csvBody := "list_name,item_id,note\nİçe Aktarım,1,\nİçe Aktarım,3,\nİçe Aktarım,,\n"
+
+		request := httptest.NewRequest("POST", "/lists/import", strings.NewReader(csvBody))
+
+		request.Header.Set("Content-Type", "text/csv")
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusCreated, response.StatusCode)
+
+		var body struct {
+			SuccesData models.ImportReport
+		}
+
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&body))
+
+		report := body.SuccesData
+
+		assert.True(t, report.Committed)
+		assert.Equal(t, 1, report.ListsCreated)
+		assert.Equal(t, 2, report.ItemsCreated)
+		assert.Equal(t, 1, report.Duplicates)
+
+		importedListId = report.Rows[0].ListId
+
+		assert.NotZero(t, importedListId)
+
+		for _, row := range report.Rows {
+			assert.Equal(t, importedListId, row.ListId)
+		}
+
+	})
+
+	t.Run("TestImportFavoritesHandleDeduplicateExistingList", func(t *testing.T) {
+
+		response := importJSON(t, "/lists/import?dryRun=true", models.ImportRequest{
+			Lists: []models.ImportList{{ListName: " içe aktarım ", ItemIds: []int{1, 4}}},
+		})
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+		var body struct {
+			SuccesData models.ImportReport
+		}
+
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&body))
+
+		report := body.SuccesData
+
+		assert.Zero(t, report.ListsCreated)
+		assert.Equal(t, 1, report.ItemsCreated)
+
+		assert.Equal(t, models.ImportRowStatusDuplicate, report.Rows[0].Status)
+		assert.Equal(t, models.ImportRowStatusCreated, report.Rows[1].Status)
+		assert.False(t, report.Rows[1].NewList)
+		assert.Equal(t, importedListId, report.Rows[1].ListId)
+
+	})
+
+	t.Run("TestImportFavoritesHandleInvalidCSVHeader", func(t *testing.T) {
+
+		request := httptest.NewRequest("POST", "/lists/import", strings.NewReader("name,product\nListe,1\n"))
+
+		request.Header.Set("Content-Type", "text/csv")
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusBadRequest, response.StatusCode)
+
+	})
+
+	t.Run("TestImportFavoritesHandleEmpty", func(t *testing.T) {
+
+		response := importJSON(t, "/lists/import", models.ImportRequest{})
+
+		assert.Equal(t, fiber.StatusBadRequest, response.StatusCode)
+
+	})
+
+	t.Run("TestImportFavoritesHandleUnauthorized", func(t *testing.T) {
+
+		request := httptest.NewRequest("POST", "/lists/import", strings.NewReader(`{"lists":[]}`))
+
+		request.Header.Set("Content-Type", "application/json")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusUnauthorized, response.StatusCode)
+
+	})
+
+	importAccount := func(t *testing.T, path string, token string, body string) *http.Response {
+
+		request := httptest.NewRequest("POST", path, strings.NewReader(body))
+
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("Authorization", token)
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		return response
+	}
+
+	t.Run("TestImportFromAccountHandleDryRun", func(t *testing.T) {
+
+		response := importAccount(t, "/lists/import/account?dryRun=true", "1", `{"source_token":"2"}`)
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+		var body struct {
+			SuccesData models.ImportReport
+		}
+
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&body))
+
+		assert.True(t, body.SuccesData.DryRun)
+		assert.False(t, body.SuccesData.Committed)
+		assert.NotEmpty(t, body.SuccesData.Rows)
+
+	})
+
+	t.Run("TestImportFromAccountHandleSameAccount", func(t *testing.T) {
+
+		response := importAccount(t, "/lists/import/account", "1", `{"source_token":"1"}`)
+
+		assert.Equal(t, fiber.StatusBadRequest, response.StatusCode)
+
+	})
+
+	t.Run("TestImportFromAccountHandleMissingSourceToken", func(t *testing.T) {
+
+		response := importAccount(t, "/lists/import/account", "1", `{}`)
+
+		assert.Equal(t, fiber.StatusBadRequest, response.StatusCode)
+
+	})
+
+	t.Run("TestImportFromAccountHandleInvalidSourceToken", func(t *testing.T) {
+
+		response := importAccount(t, "/lists/import/account", "1", `{"source_token":"99"}`)
+
+		assert.Equal(t, fiber.StatusUnauthorized, response.StatusCode)
+
+	})
+
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"favorite_service/pkg/psql"
+	"favorite_service/pkg/ratelimit"
 	"fmt"
 	"os"
 	"testing"
@@ -23,33 +24,110 @@ var app *fiber.App = fiber.New()
 
 var ctx context.Context = context.Background()
 
+const testServiceToken = "test-service-token"
+
+const testAdminToken = "test-admin-token"
+
+var testQuotaLimits = models.QuotaLimits{MaxListsPerUser: 20, MaxItemsPerList: 50}
+
+var testSunset = time.Date(2027, time.June, 30, 0, 0, 0, 0, time.UTC)
+
 type TestDB struct {
 	DB        *gorm.DB
 	Container testcontainers.Container
 }
 
 type HandlerSetup struct {
-	DB                *gorm.DB
-	App               *fiber.App
-	MockUserClient    *MockUserClient
-	MockProductClient *MockProductClient
-	MockMetrics       *MockMetrics
+	DB                   *gorm.DB
+	App                  *fiber.App
+	V1                   fiber.Router
+	MockUserClient       *MockUserClient
+	MockProductClient    *MockProductClient
+	FavoriteCountService *services.FavoriteCountService
+	QuotaService         *services.QuotaService
+}
+
+func (h *HandlerSetup) SetupAuditHandler() {
+	auditHandler := NewAuditHandler(services.NewAuditService(repositories.NewAuditRepository(h.DB)), testAdminToken)
+	auditHandler.SetRoutes(h.App)
+}
+
+func (h *HandlerSetup) SetupPrivacyHandler() {
+	privacyService := services.NewPrivacyService(repositories.NewPrivacyRepository(h.DB), repositories.NewFavoriteListRepository(h.DB), repositories.NewFavoriteItemRepository(h.DB), h.MockUserClient, h.FavoriteCountService, psql.NewTransactor(h.DB), repositories.NewOutboxRepository(h.DB))
+	privacyHandler := NewPrivacyHandler(privacyService, testServiceToken)
+	privacyHandler.SetRoutes(h.App)
+}
+
+func (h *HandlerSetup) SetupIdempotency() {
+	idempotencyService := services.NewIdempotencyService(repositories.NewIdempotencyRepository(h.DB), time.Hour, time.Minute)
+	// Idempotency, kullanıcıyı RateLimit'in doğruladığı kimlikten okur; limitler testleri etkilemeyecek kadar yüksektir.
+	h.App.Use(RateLimit(RateLimitConfig{
+		Store:       ratelimit.NewMemoryStore(),
+		UserClient:  h.MockUserClient,
+		Default:     ratelimit.Limit{Requests: 1000000, Per: time.Minute},
+		VerifyLimit: ratelimit.Limit{Requests: 1000000, Per: time.Minute},
+	}))
+	h.App.Use(Idempotency(idempotencyService))
 }
 
 func (h *HandlerSetup) SetupTestItemHandler() {
 	itemRepository := repositories.NewFavoriteItemRepository(h.DB)
 	listRepository := repositories.NewFavoriteListRepository(h.DB)
-	itemService := services.NewFavoriItemService(itemRepository, listRepository, h.MockProductClient, h.MockUserClient)
-	itemHandler := NewFavoriteItemHandler(itemService, h.MockMetrics)
+	itemService := services.NewFavoriItemService(itemRepository, listRepository, h.MockProductClient, h.MockUserClient, h.FavoriteCountService, psql.NewTransactor(h.DB), repositories.NewOutboxRepository(h.DB), h.QuotaService, repositories.NewAuditRepository(h.DB))
+	itemHandler := NewFavoriteItemHandler(itemService)
 	itemHandler.SetRoutes(h.App)
+	itemHandler.SetRoutes(h.V1)
 }
 
 func (h *HandlerSetup) SetupListHandler() {
 	listRepository := repositories.NewFavoriteListRepository(h.DB)
 	itemRepository := repositories.NewFavoriteItemRepository(h.DB)
-	favoriteListService := services.NewFavoriteListService(listRepository, itemRepository, h.MockProductClient, h.MockUserClient)
-	favoriteListHandler := NewFavoriteListHandler(favoriteListService, h.MockMetrics)
+	favoriteListService := services.NewFavoriteListService(listRepository, itemRepository, h.MockProductClient, h.MockUserClient, h.FavoriteCountService, psql.NewTransactor(h.DB), repositories.NewOutboxRepository(h.DB), 2, h.QuotaService, repositories.NewAuditRepository(h.DB))
+	favoriteListHandler := NewFavoriteListHandler(favoriteListService)
 	favoriteListHandler.SetRoutes(h.App)
+	favoriteListHandler.SetRoutes(h.V1)
+}
+
+func (h *HandlerSetup) SetupImportHandler() {
+	importService := services.NewImportService(repositories.NewFavoriteListRepository(h.DB), repositories.NewFavoriteItemRepository(h.DB), h.MockProductClient, h.MockUserClient, h.FavoriteCountService, psql.NewTransactor(h.DB), repositories.NewOutboxRepository(h.DB), h.QuotaService, repositories.NewAuditRepository(h.DB), 2)
+	importHandler := NewImportHandler(importService)
+	importHandler.SetRoutes(h.App)
+}
+
+func (h *HandlerSetup) SetupV2Handler() {
+	listRepository := repositories.NewFavoriteListRepository(h.DB)
+	itemRepository := repositories.NewFavoriteItemRepository(h.DB)
+	transactor := psql.NewTransactor(h.DB)
+	outboxRepository := repositories.NewOutboxRepository(h.DB)
+	auditRepository := repositories.NewAuditRepository(h.DB)
+	favoriteListService := services.NewFavoriteListService(listRepository, itemRepository, h.MockProductClient, h.MockUserClient, h.FavoriteCountService, transactor, outboxRepository, 2, h.QuotaService, auditRepository)
+	itemService := services.NewFavoriItemService(itemRepository, listRepository, h.MockProductClient, h.MockUserClient, h.FavoriteCountService, transactor, outboxRepository, h.QuotaService, auditRepository)
+	v2Handler := NewFavoriteV2Handler(favoriteListService, itemService)
+	v2Handler.SetRoutes(h.App.Group("/v2"))
+}
+
+func (h *HandlerSetup) SetupProductHandler() {
+	statsRepository := repositories.NewFavoriteStatsRepository(h.DB)
+	popularityService := services.NewPopularityService(statsRepository, h.MockProductClient, 2)
+	productHandler := NewProductHandler(popularityService)
+	productHandler.SetRoutes(h.App)
+}
+
+func (h *HandlerSetup) SetupFavoriteCountHandler() {
+	favoriteCountHandler := NewFavoriteCountHandler(h.FavoriteCountService, testServiceToken)
+	favoriteCountHandler.SetRoutes(h.App)
+}
+
+func (h *HandlerSetup) SetupQuotaHandler() {
+	quotaHandler := NewQuotaHandler(h.QuotaService)
+	quotaHandler.SetRoutes(h.App)
+}
+
+func (h *HandlerSetup) SetupAlertHandler() {
+	productWatchRepository := repositories.NewProductWatchRepository(h.DB)
+	productWatchService := services.NewProductWatchService(productWatchRepository, h.MockProductClient, h.MockUserClient, 10, 2)
+	alertHandler := NewAlertHandler(productWatchService)
+	alertHandler.SetRoutes(h.App)
 }
 
 func (t *TestDB) Setup() error {
@@ -123,6 +201,15 @@ func (m *MockProductClient) VerifyProduct(ctx context.Context, productId int) (*
 
 }
 
+func (m *MockProductClient) GetProduct(ctx context.Context, productId int) (*models.Product, error) {
+
+	if productId == 98 {
+		return nil, models.ErrProductServiceUnavailable
+	}
+
+	return m.VerifyProduct(ctx, productId)
+}
+
 type MockUserClient struct{}
 
 func (m *MockUserClient) VerifyUser(token string, ctx context.Context) (*models.Users, error) {
@@ -135,6 +222,12 @@ func (m *MockUserClient) VerifyUser(token string, ctx context.Context) (*models.
 			Isim:     "Ahmet",
 			Soyisim:  "Yılmaz",
 			Resim:    "https://example.com/resim1.jpg"}, nil
+	} else if token == "2" {
+		return &models.Users{
+			ID:      2,
+			Email:   "ayse.demir@example.com",
+			Isim:    "Ayşe",
+			Soyisim: "Demir"}, nil
 	} else if token == "99" {
 		return &models.Users{}, models.ErrUserUnauthorized
 	}
@@ -143,12 +236,6 @@ func (m *MockUserClient) VerifyUser(token string, ctx context.Context) (*models.
 
 }
 
-type MockMetrics struct{}
-
-func (m *MockMetrics) ObserveHandler(name string, startTime time.Time, status int) {
-
-}
-
 func TestMain(m *testing.M) {
 	testDB := &TestDB{}
 	if err := testDB.Setup(); err != nil {
@@ -157,16 +244,31 @@ func TestMain(m *testing.M) {
 	}
 	defer testDB.CleanUp()
 
+	quotaService := services.NewQuotaService(repositories.NewQuotaRepository(testDB.DB), &MockUserClient{}, testQuotaLimits)
+
 	handlerSetup := &HandlerSetup{
-		DB:                testDB.DB,
-		App:               app,
-		MockUserClient:    &MockUserClient{},
-		MockProductClient: &MockProductClient{},
-		MockMetrics:       &MockMetrics{},
+		DB:                   testDB.DB,
+		App:                  app,
+		V1:                   app.Group("/v1", Deprecated(testSunset, "/v2")),
+		MockUserClient:       &MockUserClient{},
+		MockProductClient:    &MockProductClient{},
+		FavoriteCountService: services.NewFavoriteCountService(repositories.NewFavoriteStatsRepository(testDB.DB), time.Minute),
+		QuotaService:         quotaService,
 	}
 
+	app.Use(RequestId())
+
+	handlerSetup.SetupIdempotency()
 	handlerSetup.SetupTestItemHandler()
 	handlerSetup.SetupListHandler()
+	handlerSetup.SetupImportHandler()
+	handlerSetup.SetupProductHandler()
+	handlerSetup.SetupFavoriteCountHandler()
+	handlerSetup.SetupAlertHandler()
+	handlerSetup.SetupV2Handler()
+	handlerSetup.SetupQuotaHandler()
+	handlerSetup.SetupAuditHandler()
+	handlerSetup.SetupPrivacyHandler()
 
 	os.Exit(m.Run())
 }
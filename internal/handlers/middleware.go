@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"favorite_service/internal/models"
+	"favorite_service/logs"
+	"favorite_service/pkg/requestid"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const (
+	serviceTokenHeader = "X-Service-Token"
+	adminTokenHeader   = "X-Admin-Token"
+)
+
+var requestIdPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequireServiceToken servisler arası endpointleri kullanıcı token'ı yerine
+// paylaşılan servis token'ı ile korur. Token tanımlı değilse tüm istekler reddedilir.
+func RequireServiceToken(serviceToken string) fiber.Handler {
+	return requireHeaderToken(serviceTokenHeader, serviceToken, "RequireServiceToken")
+}
+
+func RequireAdminToken(adminToken string) fiber.Handler {
+	return requireHeaderToken(adminTokenHeader, adminToken, "RequireAdminToken")
+}
+
+func requireHeaderToken(header string, expected string, handlerName string) fiber.Handler {
+
+	return func(c *fiber.Ctx) error {
+
+		token := c.Get(header)
+
+		if expected == "" || subtle.ConstantTimeCompare([]byte(token), []byte(expected)) != 1 {
+
+			logs.Warning("Token Hatasi",
+				logs.WithHandlerName(handlerName),
+				logs.WithStatus(fiber.StatusUnauthorized),
+			)
+
+			return c.Status(fiber.StatusUnauthorized).JSON(models.ErorResponse{
+				Error:   "Token Hatasi",
+				Details: header},
+			)
+		}
+
+		return c.Next()
+	}
+}
+
+// Deprecated eski API sürümündeki yanıtlara Deprecation, Sunset ve successor-version
+// Link header'larını ekler.
+func Deprecated(sunset time.Time, successor string) fiber.Handler {
+
+	sunsetHeader := sunset.UTC().Format(http.TimeFormat)
+	linkHeader := fmt.Sprintf("<%s>; rel=\"successor-version\"", successor)
+
+	return func(c *fiber.Ctx) error {
+
+		c.Set("Deprecation", "true")
+		c.Set("Sunset", sunsetHeader)
+		c.Set(fiber.HeaderLink, linkHeader)
+
+		return c.Next()
+	}
+}
+
+// RequestId istemcinin gönderdiği X-Request-ID'yi (geçerliyse) kullanır, yoksa yeni bir id üretir.
+// Id yanıta eklenir ve audit kayıtları için isteğin context'ine taşınır.
+func RequestId() fiber.Handler {
+
+	return func(c *fiber.Ctx) error {
+
+		id := c.Get(fiber.HeaderXRequestID)
+		if !requestIdPattern.MatchString(id) {
+			id = uuid.NewString()
+		}
+
+		c.Set(fiber.HeaderXRequestID, id)
+		c.SetUserContext(requestid.NewContext(c.UserContext(), id))
+
+		return c.Next()
+	}
+}
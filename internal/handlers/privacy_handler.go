@@ -0,0 +1,308 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"favorite_service/internal/models"
+	"favorite_service/logs"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-swagno/swagno/components/endpoint"
+	"github.com/go-swagno/swagno/components/http/response"
+	"github.com/go-swagno/swagno/components/parameter"
+	"github.com/gofiber/fiber/v2"
+)
+
+type privacyService interface {
+	ExportUserData(token string, ctx context.Context) (models.UserDataExport, error)
+	EraseMe(token string, ctx context.Context) (models.ErasureResult, error)
+	EraseUser(ctx context.Context, userId int) (models.ErasureResult, error)
+}
+
+type PrivacyHandler struct {
+	privacyService privacyService
+	serviceToken   string
+}
+
+func NewPrivacyHandler(privacyService privacyService, serviceToken string) *PrivacyHandler {
+	return &PrivacyHandler{
+		privacyService: privacyService,
+		serviceToken:   serviceToken,
+	}
+}
+
+var exportCSVHeader = []string{
+	"list_id", "list_name", "list_type", "visibility", "is_default", "list_created_date", "list_updated_date",
+	"item_id", "item_created_date", "favorited_price", "favorited_stock", "position", "note", "quantity", "priority", "variant",
+}
+
+func (h *PrivacyHandler) ExportUserDataHandle(c *fiber.Ctx) error {
+
+	authHeader := c.Get("Authorization")
+	if authHeader == "" {
+
+		logs.Warning("Token Authorization Hatasi",
+			logs.WithHandlerName("PrivacyHandler_ExportUserData"),
+			logs.WithStatus(fiber.StatusUnauthorized),
+		)
+
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErorResponse{
+			Error:   "Token Authorization Hatasi",
+			Details: "Token"},
+		)
+	}
+
+	format := c.Query("format", models.ExportFormatJSON)
+
+	if format != models.ExportFormatJSON && format != models.ExportFormatCSV {
+
+		logs.Warning("Geçersiz format: "+format,
+			logs.WithHandlerName("PrivacyHandler_ExportUserData"),
+			logs.WithStatus(fiber.StatusBadRequest),
+		)
+
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Validate Hatasi",
+			Details: "format json veya csv olmalıdır"},
+		)
+	}
+
+	ctx := c.UserContext()
+
+	export, err := h.privacyService.ExportUserData(authHeader, ctx)
+
+	if err != nil {
+		return privacyServiceError(c, "PrivacyHandler_ExportUserData", err)
+	}
+
+	filename := fmt.Sprintf("favorites-export-%d.%s", export.UserId, format)
+
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+filename+`"`)
+	c.Set(fiber.HeaderCacheControl, "no-store")
+
+	if format == models.ExportFormatJSON {
+		return c.Status(fiber.StatusOK).JSON(export)
+	}
+
+	body, err := exportToCSV(export)
+
+	if err != nil {
+
+		logs.Error(err.Error(),
+			logs.WithHandlerName("PrivacyHandler_ExportUserData"),
+			logs.WithStatus(fiber.StatusInternalServerError),
+		)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErorResponse{
+			Error:   "Servis Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+
+	return c.Status(fiber.StatusOK).Send(body)
+
+}
+
+// exportToCSV her ürün için bir satır yazar. Ürünü olmayan listeler de kaybolmasın diye
+// ürün sütunları boş tek bir satırla yazılır.
+func exportToCSV(export models.UserDataExport) ([]byte, error) {
+
+	var buf bytes.Buffer
+
+	writer := csv.NewWriter(&buf)
+
+	if err := writer.Write(exportCSVHeader); err != nil {
+		return nil, err
+	}
+
+	for _, list := range export.Lists {
+
+		listColumns := []string{
+			strconv.Itoa(list.Id),
+			list.ListName,
+			list.ListType,
+			list.Visibility,
+			strconv.FormatBool(list.IsDefault),
+			list.CreatedDate.UTC().Format(time.RFC3339),
+			list.UpdatedDate.UTC().Format(time.RFC3339),
+		}
+
+		if len(list.Items) == 0 {
+			if err := writer.Write(append(listColumns, make([]string, len(exportCSVHeader)-len(listColumns))...)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		for _, item := range list.Items {
+
+			price, stock := "", ""
+			if item.FavoritedPrice != nil {
+				price = strconv.FormatFloat(*item.FavoritedPrice, 'f', 2, 64)
+			}
+			if item.FavoritedStock != nil {
+				stock = strconv.Itoa(*item.FavoritedStock)
+			}
+
+			row := append(append([]string{}, listColumns...),
+				strconv.Itoa(item.ItemId),
+				item.CreatedDate.UTC().Format(time.RFC3339),
+				price,
+				stock,
+				strconv.Itoa(item.Position),
+				item.Note,
+				strconv.Itoa(item.Quantity),
+				item.Priority,
+				item.Variant,
+			)
+
+			if err := writer.Write(row); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	writer.Flush()
+
+	return buf.Bytes(), writer.Error()
+}
+
+func (h *PrivacyHandler) EraseMeHandle(c *fiber.Ctx) error {
+
+	authHeader := c.Get("Authorization")
+	if authHeader == "" {
+
+		logs.Warning("Token Authorization Hatasi",
+			logs.WithHandlerName("PrivacyHandler_EraseMe"),
+			logs.WithStatus(fiber.StatusUnauthorized),
+		)
+
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErorResponse{
+			Error:   "Token Authorization Hatasi",
+			Details: "Token"},
+		)
+	}
+
+	ctx := c.UserContext()
+
+	result, err := h.privacyService.EraseMe(authHeader, ctx)
+
+	if err != nil {
+		return privacyServiceError(c, "PrivacyHandler_EraseMe", err)
+	}
+
+	logs.Info("Kullanıcı Verileri Silindi",
+		logs.WithHandlerName("PrivacyHandler_EraseMe"),
+		logs.WithStatus(fiber.StatusOK),
+	)
+
+	return c.Status(fiber.StatusOK).JSON(models.SuccesResponse{SuccesData: result})
+
+}
+
+func (h *PrivacyHandler) EraseUserHandle(c *fiber.Ctx) error {
+
+	userId, err := c.ParamsInt("userId")
+
+	if err != nil || userId <= 0 {
+
+		logs.Warning("Geçersiz userId",
+			logs.WithHandlerName("PrivacyHandler_EraseUser"),
+			logs.WithStatus(fiber.StatusBadRequest),
+		)
+
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Validate Hatasi",
+			Details: "userId pozitif bir sayı olmalıdır"},
+		)
+	}
+
+	ctx := c.UserContext()
+
+	result, err := h.privacyService.EraseUser(ctx, userId)
+
+	if err != nil {
+		return privacyServiceError(c, "PrivacyHandler_EraseUser", err)
+	}
+
+	logs.Info("Kullanıcı Verileri Silindi",
+		logs.WithHandlerName("PrivacyHandler_EraseUser"),
+		logs.WithStatus(fiber.StatusOK),
+	)
+
+	return c.Status(fiber.StatusOK).JSON(models.SuccesResponse{SuccesData: result})
+
+}
+
+func privacyServiceError(c *fiber.Ctx, handlerName string, err error) error {
+
+	status, message := fiber.StatusInternalServerError, "Servis Hatasi"
+
+	if errors.Is(err, models.ErrUserUnauthorized) || errors.Is(err, models.ErrUserNotFound) {
+		status, message = fiber.StatusUnauthorized, "Token Authorization Hatasi"
+	}
+
+	logs.Error(err.Error(),
+		logs.WithHandlerName(handlerName),
+		logs.WithStatus(status),
+	)
+
+	return c.Status(status).JSON(models.ErorResponse{
+		Error:   message,
+		Details: err.Error()},
+	)
+}
+
+func (h *PrivacyHandler) SetRoutes(app *fiber.App) {
+
+	meGroup := app.Group("/me")
+
+	meGroup.Get("/export", h.ExportUserDataHandle)
+	meGroup.Delete("/", h.EraseMeHandle)
+
+	internalGroup := app.Group("/internal/users", RequireServiceToken(h.serviceToken))
+
+	internalGroup.Delete("/:userId", h.EraseUserHandle)
+
+}
+
+func PrivacyGetEndpoints() []*endpoint.EndPoint {
+	return []*endpoint.EndPoint{
+		endpoint.New(
+			endpoint.GET,
+			"/me/export",
+			endpoint.WithTags("privacy"),
+			endpoint.WithParams(
+				parameter.StrParam("Authorization", parameter.Header, parameter.WithRequired()),
+				parameter.StrParam("format", parameter.Query, parameter.WithDescription("json (varsayılan) veya csv")),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New(models.UserDataExport{}, "200", "OK")}),
+			endpoint.WithErrors([]response.Response{response.New(models.ErorResponse{}, "400", "Bad Request")}),
+		),
+		endpoint.New(
+			endpoint.DELETE,
+			"/me",
+			endpoint.WithTags("privacy"),
+			endpoint.WithParams(parameter.StrParam("Authorization", parameter.Header, parameter.WithRequired())),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New(models.ErasureResult{}, "200", "OK")}),
+			endpoint.WithErrors([]response.Response{response.New(models.ErorResponse{}, "401", "Unauthorized")}),
+		),
+		endpoint.New(
+			endpoint.DELETE,
+			"/internal/users/{userId}",
+			endpoint.WithTags("internal"),
+			endpoint.WithParams(
+				parameter.StrParam(serviceTokenHeader, parameter.Header, parameter.WithRequired()),
+				parameter.IntParam("userId", parameter.Path, parameter.WithRequired()),
+			),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New(models.ErasureResult{}, "200", "OK")}),
+			endpoint.WithErrors([]response.Response{response.New(models.ErorResponse{}, "400", "Bad Request")}),
+		),
+	}
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"favorite_service/internal/models"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrivacyHandler(t *testing.T) {
+
+	t.Run("TestExportUserDataHandleJSON", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/me/export", nil)
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+		assert.Contains(t, response.Header.Get(fiber.HeaderContentDisposition), "attachment")
+
+		var export models.UserDataExport
+
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&export))
+
+		assert.Equal(t, 1, export.UserId)
+
+		assert.NotEmpty(t, export.Lists)
+
+		for _, list := range export.Lists {
+			assert.Equal(t, 1, list.UserId)
+			assert.NotNil(t, list.Items)
+		}
+
+	})
+
+	t.Run("TestExportUserDataHandleCSV", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/me/export?format=csv", nil)
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+		assert.Contains(t, response.Header.Get(fiber.HeaderContentType), "text/csv")
+
+		records, err := csv.NewReader(response.Body).ReadAll()
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, exportCSVHeader, records[0])
+
+		assert.Greater(t, len(records), 1)
+
+	})
+
+	t.Run("TestExportUserDataHandleInvalidFormat", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/me/export?format=xml", nil)
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusBadRequest, response.StatusCode)
+
+	})
+
+	t.Run("TestExportUserDataHandleUnauthorized", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/me/export", nil)
+
+		request.Header.Set("Authorization", "99")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusUnauthorized, response.StatusCode)
+
+	})
+
+	t.Run("TestEraseMeHandleUnauthorized", func(t *testing.T) {
+
+		request := httptest.NewRequest("DELETE", "/me", nil)
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusUnauthorized, response.StatusCode)
+
+	})
+
+	t.Run("TestEraseUserHandle", func(t *testing.T) {
+
+		request := httptest.NewRequest("DELETE", "/internal/users/4242", nil)
+
+		request.Header.Set(serviceTokenHeader, testServiceToken)
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+		var body struct {
+			SuccesData models.ErasureResult
+		}
+
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&body))
+
+		assert.Equal(t, models.ErasureResult{UserId: 4242}, body.SuccesData)
+
+	})
+
+	t.Run("TestEraseUserHandleInvalidToken", func(t *testing.T) {
+
+		request := httptest.NewRequest("DELETE", "/internal/users/4242", nil)
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusUnauthorized, response.StatusCode)
+
+	})
+
+	t.Run("TestEraseUserHandleInvalidUserId", func(t *testing.T) {
+
+		request := httptest.NewRequest("DELETE", "/internal/users/abc", nil)
+
+		request.Header.Set(serviceTokenHeader, testServiceToken)
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusBadRequest, response.StatusCode)
+
+	})
+
+}
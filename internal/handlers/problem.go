@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"favorite_service/internal/models"
+	"favorite_service/logs"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const problemContentType = "application/problem+json"
+
+func writeProblem(c *fiber.Ctx, status int, title string, detail string) error {
+
+	return c.Status(status).JSON(models.Problem{
+		Type:     "about:blank",
+		Title:    title,
+		Status:   status,
+		Detail:   detail,
+		Instance: c.OriginalURL(),
+	}, problemContentType)
+}
+
+// serviceProblem servis katmanından gelen hatayı uygun HTTP status koduna çevirip problem olarak yazar.
+func serviceProblem(c *fiber.Ctx, handlerName string, err error) error {
+
+	status, title := fiber.StatusInternalServerError, "Servis Hatasi"
+
+	var quotaErr *models.QuotaExceededError
+
+	switch {
+	case errors.As(err, &quotaErr):
+		status, title = fiber.StatusUnprocessableEntity, "Kota Aşıldı"
+	case errors.Is(err, models.ErrUserUnauthorized), errors.Is(err, models.ErrUserNotFound):
+		status, title = fiber.StatusUnauthorized, "Token Authorization Hatasi"
+	case errors.Is(err, models.ErrunaUthorizedAction):
+		status, title = fiber.StatusForbidden, "Yetkisiz Islem"
+	case errors.Is(err, models.ErrVersionMismatch):
+		status, title = fiber.StatusPreconditionFailed, "On Kosul Saglanmadi"
+	case errors.Is(err, models.ErrRecordNotFound):
+		status, title = fiber.StatusNotFound, "Kayıt Bulunamadi"
+	case errors.Is(err, models.ErrProductNotFound):
+		status, title = fiber.StatusNotFound, "Ürün Bulunamadi"
+	case errors.Is(err, context.DeadlineExceeded):
+		status, title = fiber.StatusGatewayTimeout, "Zaman Asimi"
+	}
+
+	if status >= fiber.StatusInternalServerError {
+		logs.Error(err.Error(), logs.WithHandlerName(handlerName), logs.WithStatus(status))
+	} else {
+		logs.Warning(err.Error(), logs.WithHandlerName(handlerName), logs.WithStatus(status))
+	}
+
+	return writeProblem(c, status, title, err.Error())
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"context"
+	"favorite_service/internal/models"
+	"favorite_service/logs"
+	"fmt"
+	"time"
+
+	"github.com/go-swagno/swagno/components/endpoint"
+	"github.com/go-swagno/swagno/components/http/response"
+	"github.com/go-swagno/swagno/components/parameter"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	defaultPopularWindow = "7d"
+	defaultPopularLimit  = 20
+	maxPopularLimit      = 100
+)
+
+// popularWindows kabul edilen window değerleridir. Özet tablo gün bazında tutulduğu için
+// gün altı değerler kabul edilmez; liste sınırlı olduğu için önbellek de sınırlı kalır.
+var popularWindows = map[string]time.Duration{
+	"1d":  24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+	"30d": 30 * 24 * time.Hour,
+	"90d": 90 * 24 * time.Hour,
+}
+
+type popularityService interface {
+	GetPopularProducts(ctx context.Context, window time.Duration, limit int) ([]models.PopularProduct, error)
+}
+
+type ProductHandler struct {
+	popularityService popularityService
+}
+
+func NewProductHandler(popularityService popularityService) *ProductHandler {
+	return &ProductHandler{
+		popularityService: popularityService,
+	}
+}
+
+func (h *ProductHandler) GetPopularProductsHandle(c *fiber.Ctx) error {
+
+	window, err := parseWindow(c.Query("window"))
+
+	if err != nil {
+
+		logs.Warning(err.Error(),
+			logs.WithHandlerName("ProductHandler_GetPopularProducts"),
+			logs.WithStatus(fiber.StatusBadRequest),
+		)
+
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Window Parametre Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	limit := c.QueryInt("limit", defaultPopularLimit)
+
+	if limit < 1 || limit > maxPopularLimit {
+
+		logs.Warning("Limit aralık dışında",
+			logs.WithHandlerName("ProductHandler_GetPopularProducts"),
+			logs.WithStatus(fiber.StatusBadRequest),
+		)
+
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Limit Parametre Hatasi",
+			Details: fmt.Sprintf("limit 1-%d aralığında olmalı", maxPopularLimit)},
+		)
+	}
+
+	ctx := c.UserContext()
+
+	products, err := h.popularityService.GetPopularProducts(ctx, window, limit)
+
+	if err != nil {
+
+		logs.Error(err.Error(),
+			logs.WithHandlerName("ProductHandler_GetPopularProducts"),
+			logs.WithStatus(fiber.StatusInternalServerError),
+		)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErorResponse{
+			Error:   "Servis Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(models.SuccesResponse{SuccesData: products})
+
+}
+
+// parseWindow yalnızca popularWindows içindeki değerleri kabul eder.
+func parseWindow(value string) (time.Duration, error) {
+
+	if value == "" {
+		value = defaultPopularWindow
+	}
+
+	window, ok := popularWindows[value]
+	if !ok {
+		return 0, fmt.Errorf("geçersiz window: %s (1d, 7d, 30d veya 90d olmalı)", value)
+	}
+
+	return window, nil
+}
+
+func (h *ProductHandler) SetRoutes(app *fiber.App) {
+
+	productGroup := app.Group("/products")
+
+	productGroup.Get("/popular", h.GetPopularProductsHandle)
+
+}
+
+func ProductGetEndpoints() []*endpoint.EndPoint {
+	return []*endpoint.EndPoint{
+		endpoint.New(
+			endpoint.GET,
+			"/products/popular",
+			endpoint.WithTags("products"),
+			endpoint.WithParams(parameter.StrParam("window", parameter.Query, parameter.WithDefault(defaultPopularWindow), parameter.WithDescription("1d, 7d, 30d, 90d"))),
+			endpoint.WithParams(parameter.IntParam("limit", parameter.Query, parameter.WithDefault(defaultPopularLimit))),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New([]models.PopularProduct{}, "200", "OK")}),
+			endpoint.WithErrors([]response.Response{response.New(models.ErorResponse{}, "400", "Bad Request")}),
+		),
+	}
+}
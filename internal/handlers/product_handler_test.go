@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductHandler(t *testing.T) {
+
+	t.Run("TestGetPopularProductsHandle", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/products/popular?window=7d&limit=5", nil)
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+	})
+
+	t.Run("TestGetPopularProductsHandleBadWindow", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/products/popular?window=abc", nil)
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusBadRequest, response.StatusCode)
+
+	})
+
+	t.Run("TestGetPopularProductsHandleSubDayWindow", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/products/popular?window=90m", nil)
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusBadRequest, response.StatusCode)
+
+	})
+
+	t.Run("TestGetPopularProductsHandleBadLimit", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/products/popular?limit=1000", nil)
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusBadRequest, response.StatusCode)
+
+	})
+
+}
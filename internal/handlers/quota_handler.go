@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"favorite_service/internal/models"
+	"favorite_service/logs"
+
+	"github.com/go-swagno/swagno/components/endpoint"
+	"github.com/go-swagno/swagno/components/http/response"
+	"github.com/go-swagno/swagno/components/parameter"
+	"github.com/gofiber/fiber/v2"
+)
+
+type quotaService interface {
+	GetUserQuotaUsage(token string, ctx context.Context) (models.UserQuotaUsage, error)
+}
+
+type QuotaHandler struct {
+	quotaService quotaService
+}
+
+func NewQuotaHandler(quotaService quotaService) *QuotaHandler {
+	return &QuotaHandler{
+		quotaService: quotaService,
+	}
+}
+
+func (h *QuotaHandler) GetUserQuotaUsageHandle(c *fiber.Ctx) error {
+
+	authHeader := c.Get("Authorization")
+	if authHeader == "" {
+
+		logs.Warning("Token Authorization Hatasi",
+			logs.WithHandlerName("QuotaHandler_GetUserQuotaUsage"),
+			logs.WithStatus(fiber.StatusUnauthorized),
+		)
+
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErorResponse{
+			Error:   "Token Authorization Hatasi",
+			Details: "Token"},
+		)
+	}
+
+	ctx := c.UserContext()
+
+	usage, err := h.quotaService.GetUserQuotaUsage(authHeader, ctx)
+
+	if err != nil {
+
+		if errors.Is(err, models.ErrUserUnauthorized) || errors.Is(err, models.ErrUserNotFound) {
+
+			logs.Warning(err.Error(),
+				logs.WithHandlerName("QuotaHandler_GetUserQuotaUsage"),
+				logs.WithStatus(fiber.StatusUnauthorized),
+			)
+
+			return c.Status(fiber.StatusUnauthorized).JSON(models.ErorResponse{
+				Error:   "Token Authorization Hatasi",
+				Details: err.Error()},
+			)
+		}
+
+		logs.Error(err.Error(),
+			logs.WithHandlerName("QuotaHandler_GetUserQuotaUsage"),
+			logs.WithStatus(fiber.StatusInternalServerError),
+		)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErorResponse{
+			Error:   "Servis Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(models.SuccesResponse{SuccesData: usage})
+
+}
+
+// writeQuotaExceeded kota aşımını mevcut kullanım ve sınırla birlikte 422 olarak döner.
+func writeQuotaExceeded(c *fiber.Ctx, handlerName string, quotaErr *models.QuotaExceededError) error {
+
+	logs.Warning(quotaErr.Error(),
+		logs.WithHandlerName(handlerName),
+		logs.WithStatus(fiber.StatusUnprocessableEntity),
+	)
+
+	return c.Status(fiber.StatusUnprocessableEntity).JSON(models.QuotaErrorResponse{
+		Error:    "Kota Aşıldı",
+		Details:  quotaErr.Error(),
+		Resource: quotaErr.Resource,
+		Used:     quotaErr.Used,
+		Limit:    quotaErr.Limit,
+	})
+}
+
+func (h *QuotaHandler) SetRoutes(router fiber.Router) {
+
+	quotaGroup := router.Group("/quotas")
+
+	quotaGroup.Get("/", h.GetUserQuotaUsageHandle)
+
+}
+
+func QuotaGetEndpoints() []*endpoint.EndPoint {
+	return []*endpoint.EndPoint{
+		endpoint.New(
+			endpoint.GET,
+			"/quotas",
+			endpoint.WithTags("quotas"),
+			endpoint.WithParams(parameter.StrParam("Authorization", parameter.Header, parameter.WithRequired())),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New(models.UserQuotaUsage{}, "200", "OK")}),
+			endpoint.WithErrors([]response.Response{response.New(models.ErorResponse{}, "401", "Unauthorized")}),
+		),
+	}
+}
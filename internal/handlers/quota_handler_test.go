@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"favorite_service/internal/models"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuotaHandler(t *testing.T) {
+
+	t.Run("TestGetUserQuotaUsageHandle", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/quotas", nil)
+
+		request.Header.Set("Authorization", "1")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+
+		var body struct {
+			SuccesData models.UserQuotaUsage
+		}
+
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&body))
+
+		assert.Equal(t, testQuotaLimits.MaxListsPerUser, body.SuccesData.Lists.Limit)
+
+		assert.Len(t, body.SuccesData.ItemsPerList, body.SuccesData.Lists.Used)
+
+	})
+
+	t.Run("TestGetUserQuotaUsageHandleUnauthorized", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/quotas", nil)
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusUnauthorized, response.StatusCode)
+
+	})
+
+	t.Run("TestGetUserQuotaUsageHandleInvalidToken", func(t *testing.T) {
+
+		request := httptest.NewRequest("GET", "/quotas", nil)
+
+		request.Header.Set("Authorization", "99")
+
+		response, err := app.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusUnauthorized, response.StatusCode)
+
+	})
+
+	t.Run("TestCreateFavoriteListHandleQuotaExceeded", func(t *testing.T) {
+
+		statusCode := 0
+
+		for i := 0; i <= testQuotaLimits.MaxListsPerUser && statusCode != fiber.StatusUnprocessableEntity; i++ {
+
+			body, err := json.Marshal(models.CreateFavoriteList{ListName: fmt.Sprintf("Kota %d", i)})
+
+			assert.Nil(t, err)
+
+			request := httptest.NewRequest("POST", "/lists", bytes.NewReader(body))
+
+			request.Header.Set("Content-Type", "application/json")
+
+			request.Header.Set("Authorization", "1")
+
+			response, err := app.Test(request)
+
+			assert.Nil(t, err)
+
+			statusCode = response.StatusCode
+
+			if statusCode == fiber.StatusUnprocessableEntity {
+
+				var quotaErr models.QuotaErrorResponse
+
+				assert.Nil(t, json.NewDecoder(response.Body).Decode(&quotaErr))
+
+				assert.Equal(t, models.QuotaResourceLists, quotaErr.Resource)
+
+				assert.Equal(t, testQuotaLimits.MaxListsPerUser, quotaErr.Limit)
+			}
+		}
+
+		assert.Equal(t, fiber.StatusUnprocessableEntity, statusCode)
+
+	})
+
+}
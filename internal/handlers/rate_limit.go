@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"context"
+	"favorite_service/internal/models"
+	"favorite_service/logs"
+	"favorite_service/metrics"
+	"favorite_service/pkg/ratelimit"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const maxCachedIdentities = 10000
+
+// defaultVerifyLimit bir IP'nin dakikada user servisine doğrulatabileceği en fazla bilinmeyen token sayısıdır.
+var defaultVerifyLimit = ratelimit.Limit{Requests: 20, Per: time.Minute}
+
+type rateLimitUserClient interface {
+	VerifyUser(token string, ctx context.Context) (*models.Users, error)
+}
+
+// RateLimitRule, Method (boşsa tüm metodlar) ve path önekiyle eşleşen isteklere
+// ayrı bir limit uygular. Kurallar sırayla denenir, ilk eşleşen kullanılır.
+type RateLimitRule struct {
+	Name   string
+	Method string
+	Prefix string
+	Limit  ratelimit.Limit
+}
+
+type RateLimitConfig struct {
+	Store        ratelimit.Store
+	UserClient   rateLimitUserClient
+	Default      ratelimit.Limit
+	Rules        []RateLimitRule
+	SkipPrefixes []string
+	IdentityTTL  time.Duration
+	// VerifyLimit, önbellekte olmayan token'ların user servisine doğrulatılmasını IP başına sınırlar.
+	// Limit aşıldığında token doğrulanmaz ve istek IP anahtarıyla sınırlanır.
+	VerifyLimit ratelimit.Limit
+}
+
+type cachedIdentity struct {
+	user    *models.Users
+	expires time.Time
+}
+
+// identityCache doğrulanmış token -> kullanıcı eşlemesini kısa süre saklar; böylece
+// limit anahtarı için her istekte user servisine gidilmez.
+type identityCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedIdentity
+	ttl     time.Duration
+}
+
+func (c *identityCache) get(key string, now time.Time) (cachedIdentity, bool) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || now.After(entry.expires) {
+		return cachedIdentity{}, false
+	}
+
+	return entry, true
+}
+
+func (c *identityCache) set(key string, user *models.Users, now time.Time) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= maxCachedIdentities {
+		for k, entry := range c.entries {
+			if now.After(entry.expires) {
+				delete(c.entries, k)
+			}
+		}
+	}
+
+	if len(c.entries) >= maxCachedIdentities {
+		return
+	}
+
+	c.entries[key] = cachedIdentity{user: user, expires: now.Add(c.ttl)}
+}
+
+// RateLimit istekleri token bucket ile sınırlar. Anahtar, token'ı user servisi tarafından
+// doğrulanan kullanıcının id'sidir; token yoksa, geçersizse veya IP'nin doğrulama limiti dolmuşsa
+// istemci IP'si kullanılır. Store hatasında istek engellenmez. Doğrulanan kullanıcı c.Locals'a ve
+// istek context'ine eklenir; sonraki middleware'ler ve servisler token'ı tekrar doğrulamaz.
+func RateLimit(config RateLimitConfig) fiber.Handler {
+
+	ttl := config.IdentityTTL
+	if ttl <= 0 {
+		ttl = time.Minute
+	}
+
+	if config.VerifyLimit.Requests <= 0 {
+		config.VerifyLimit = defaultVerifyLimit
+	}
+
+	identities := &identityCache{entries: make(map[string]cachedIdentity), ttl: ttl}
+
+	return func(c *fiber.Ctx) error {
+
+		path := c.Path()
+
+		for _, prefix := range config.SkipPrefixes {
+			if strings.HasPrefix(path, prefix) {
+				return c.Next()
+			}
+		}
+
+		rule := matchRateLimitRule(config, c.Method(), path)
+
+		keyType, identity, user := rateLimitIdentity(c, config, identities)
+
+		if user != nil {
+			c.Locals(verifiedUserLocal, user)
+			c.SetUserContext(withVerifiedUser(c.UserContext(), c.Get("Authorization"), user))
+		}
+
+		result, err := config.Store.Take(c.UserContext(), rule.Name+":"+keyType+":"+identity, rule.Limit)
+		if err != nil {
+
+			logs.Error("Rate limit store hatasi: "+err.Error(),
+				logs.WithHandlerName("RateLimit"),
+			)
+
+			return c.Next()
+		}
+
+		c.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+
+		if !result.Allowed {
+
+			metrics.RateLimitRejected.WithLabelValues(rule.Name, keyType).Inc()
+
+			logs.Warning("Istek limiti asildi",
+				logs.WithHandlerName("RateLimit"),
+				logs.WithStatus(fiber.StatusTooManyRequests),
+			)
+
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(ceilSeconds(result.RetryAfter)))
+
+			return c.Status(fiber.StatusTooManyRequests).JSON(models.ErorResponse{
+				Error:   "Istek limiti asildi",
+				Details: rule.Name},
+			)
+		}
+
+		return c.Next()
+	}
+}
+
+func matchRateLimitRule(config RateLimitConfig, method string, path string) RateLimitRule {
+
+	for _, rule := range config.Rules {
+		if (rule.Method == "" || rule.Method == method) && strings.HasPrefix(path, rule.Prefix) {
+			return rule
+		}
+	}
+
+	return RateLimitRule{Name: "default", Limit: config.Default}
+}
+
+func rateLimitIdentity(c *fiber.Ctx, config RateLimitConfig, identities *identityCache) (string, string, *models.Users) {
+
+	token := c.Get("Authorization")
+
+	if token == "" || config.UserClient == nil {
+		return "ip", c.IP(), nil
+	}
+
+	tokenKey := sha256Hex([]byte(token))
+	now := time.Now()
+
+	entry, ok := identities.get(tokenKey, now)
+	if !ok {
+
+		// Bilinmeyen token'lar user servisine gitmeden önce IP başına doğrulama bucket'ından geçer;
+		// rastgele token'larla gelen istemci user servisine yük bindiremez.
+		result, err := config.Store.Take(c.UserContext(), "verify:ip:"+c.IP(), config.VerifyLimit)
+		if err != nil || !result.Allowed {
+			return "ip", c.IP(), nil
+		}
+
+		// Geçersiz token'lar da nil olarak saklanır; aksi halde her istek user servisine gider.
+		user, err := config.UserClient.VerifyUser(token, c.UserContext())
+		if err != nil || user == nil || user.ID == 0 {
+			user = nil
+		}
+
+		identities.set(tokenKey, user, now)
+		entry = cachedIdentity{user: user}
+	}
+
+	if entry.user == nil {
+		return "ip", c.IP(), nil
+	}
+
+	return "user", strconv.Itoa(entry.user.ID), entry.user
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
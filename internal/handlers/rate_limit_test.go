@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"context"
+	"favorite_service/internal/models"
+	"favorite_service/pkg/ratelimit"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type countingUserClient struct {
+	calls int
+}
+
+func (m *countingUserClient) VerifyUser(token string, ctx context.Context) (*models.Users, error) {
+	m.calls++
+	if token == "1" {
+		return &models.Users{ID: 1}, nil
+	}
+	return nil, models.ErrUserUnauthorized
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+
+	limiterApp := fiber.New()
+
+	limiterApp.Use(RateLimit(RateLimitConfig{
+		Store:      ratelimit.NewMemoryStore(),
+		UserClient: &MockUserClient{},
+		Default:    ratelimit.Limit{Requests: 2, Per: time.Minute},
+		Rules: []RateLimitRule{
+			{Name: "write", Method: fiber.MethodPost, Limit: ratelimit.Limit{Requests: 1, Per: time.Minute}},
+		},
+		SkipPrefixes: []string{"/metrics"},
+	}))
+
+	ok := func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	}
+
+	limiterApp.Get("/lists", ok)
+	limiterApp.Post("/lists", ok)
+	limiterApp.Get("/metrics", ok)
+
+	do := func(method string, path string, token string) (int, map[string]string) {
+
+		request := httptest.NewRequest(method, path, nil)
+
+		if token != "" {
+			request.Header.Set("Authorization", token)
+		}
+
+		response, err := limiterApp.Test(request)
+
+		assert.Nil(t, err)
+
+		return response.StatusCode, map[string]string{
+			"limit":     response.Header.Get("RateLimit-Limit"),
+			"remaining": response.Header.Get("RateLimit-Remaining"),
+			"reset":     response.Header.Get("RateLimit-Reset"),
+			"retry":     response.Header.Get(fiber.HeaderRetryAfter),
+		}
+	}
+
+	t.Run("TestRateLimitHeaders", func(t *testing.T) {
+
+		status, headers := do("GET", "/lists", "1")
+
+		assert.Equal(t, fiber.StatusOK, status)
+
+		assert.Equal(t, "2", headers["limit"])
+
+		assert.Equal(t, "1", headers["remaining"])
+
+		assert.NotEmpty(t, headers["reset"])
+
+	})
+
+	t.Run("TestRateLimitRejectsWhenBucketEmpty", func(t *testing.T) {
+
+		status, _ := do("GET", "/lists", "1")
+
+		assert.Equal(t, fiber.StatusOK, status)
+
+		status, headers := do("GET", "/lists", "1")
+
+		assert.Equal(t, fiber.StatusTooManyRequests, status)
+
+		assert.Equal(t, "0", headers["remaining"])
+
+		assert.NotEmpty(t, headers["retry"])
+
+	})
+
+	t.Run("TestRateLimitPerRouteRule", func(t *testing.T) {
+
+		status, headers := do("POST", "/lists", "1")
+
+		assert.Equal(t, fiber.StatusOK, status)
+
+		assert.Equal(t, "1", headers["limit"])
+
+		status, _ = do("POST", "/lists", "1")
+
+		assert.Equal(t, fiber.StatusTooManyRequests, status)
+
+	})
+
+	t.Run("TestRateLimitFallsBackToIP", func(t *testing.T) {
+
+		status, headers := do("GET", "/lists", "99")
+
+		assert.Equal(t, fiber.StatusOK, status)
+
+		assert.Equal(t, "1", headers["remaining"])
+
+		status, _ = do("GET", "/lists", "")
+
+		assert.Equal(t, fiber.StatusOK, status)
+
+		status, _ = do("GET", "/lists", "")
+
+		assert.Equal(t, fiber.StatusTooManyRequests, status)
+
+	})
+
+	t.Run("TestRateLimitSkipPrefixes", func(t *testing.T) {
+
+		for i := 0; i < 5; i++ {
+
+			status, headers := do("GET", "/metrics", "")
+
+			assert.Equal(t, fiber.StatusOK, status)
+
+			assert.Empty(t, headers["limit"])
+		}
+
+	})
+
+	t.Run("TestRateLimitVerifyLimitPerIP", func(t *testing.T) {
+
+		userClient := &countingUserClient{}
+
+		verifyApp := fiber.New()
+
+		verifyApp.Use(RateLimit(RateLimitConfig{
+			Store:       ratelimit.NewMemoryStore(),
+			UserClient:  userClient,
+			Default:     ratelimit.Limit{Requests: 100, Per: time.Minute},
+			VerifyLimit: ratelimit.Limit{Requests: 2, Per: time.Minute},
+		}))
+
+		verifyApp.Get("/lists", ok)
+
+		for i := 0; i < 5; i++ {
+
+			request := httptest.NewRequest("GET", "/lists", nil)
+
+			request.Header.Set("Authorization", "sahte-"+strconv.Itoa(i))
+
+			response, err := verifyApp.Test(request)
+
+			assert.Nil(t, err)
+
+			assert.Equal(t, fiber.StatusOK, response.StatusCode)
+		}
+
+		assert.Equal(t, 2, userClient.calls)
+
+	})
+
+	t.Run("TestRateLimitExposesVerifiedUser", func(t *testing.T) {
+
+		userClient := &countingUserClient{}
+
+		contextUserClient := NewContextUserClient(userClient)
+
+		verifiedApp := fiber.New()
+
+		verifiedApp.Use(RateLimit(RateLimitConfig{
+			Store:      ratelimit.NewMemoryStore(),
+			UserClient: userClient,
+			Default:    ratelimit.Limit{Requests: 100, Per: time.Minute},
+		}))
+
+		verifiedApp.Get("/lists", func(c *fiber.Ctx) error {
+
+			user, ok := VerifiedUser(c)
+			if !ok {
+				return c.SendStatus(fiber.StatusUnauthorized)
+			}
+
+			contextUser, err := contextUserClient.VerifyUser(c.Get("Authorization"), c.UserContext())
+			if err != nil || contextUser.ID != user.ID {
+				return c.SendStatus(fiber.StatusInternalServerError)
+			}
+
+			return c.SendString(strconv.Itoa(user.ID))
+		})
+
+		for i := 0; i < 3; i++ {
+
+			request := httptest.NewRequest("GET", "/lists", nil)
+
+			request.Header.Set("Authorization", "1")
+
+			response, err := verifiedApp.Test(request)
+
+			assert.Nil(t, err)
+
+			assert.Equal(t, fiber.StatusOK, response.StatusCode)
+		}
+
+		assert.Equal(t, 1, userClient.calls)
+
+		request := httptest.NewRequest("GET", "/lists", nil)
+
+		request.Header.Set("Authorization", "sahte")
+
+		response, err := verifiedApp.Test(request)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, fiber.StatusUnauthorized, response.StatusCode)
+
+		_, err = contextUserClient.VerifyUser("2", context.Background())
+
+		assert.ErrorIs(t, err, models.ErrUserUnauthorized)
+
+	})
+
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"favorite_service/internal/models"
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// parseInclude "items,counts,products" biçimindeki include parametresini çözer.
+// Parametre boşsa ürünler dahil edilir, böylece eski istemcilerin yanıtı değişmez.
+func parseInclude(value string) (models.ResponseInclude, error) {
+
+	if strings.TrimSpace(value) == "" {
+		return models.ResponseInclude{Products: true}, nil
+	}
+
+	var include models.ResponseInclude
+
+	for _, part := range strings.Split(value, ",") {
+		switch strings.TrimSpace(part) {
+		case models.IncludeItems:
+			include.ItemIds = true
+		case models.IncludeCounts:
+			include.Counts = true
+		case models.IncludeProducts:
+			include.Products = true
+		default:
+			return models.ResponseInclude{}, fmt.Errorf("geçersiz include değeri: %q", part)
+		}
+	}
+
+	return include, nil
+}
+
+// parseFields "id,name" biçimindeki fields parametresini çözer. Boş parametre tüm alanlar demektir.
+func parseFields(value string) ([]string, error) {
+
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(value, ",")
+	fields := make([]string, 0, len(parts))
+
+	for _, part := range parts {
+		field := strings.TrimSpace(part)
+
+		if !isProductField(field) {
+			return nil, fmt.Errorf("geçersiz alan: %q", part)
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+func isProductField(field string) bool {
+
+	for _, f := range models.ProductFields {
+		if f == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sparseProducts ürünleri yalnızca istenen alanlarla döner.
+func sparseProducts(products []models.FavoriteProduct, fields []string) []fiber.Map {
+
+	sparse := make([]fiber.Map, 0, len(products))
+
+	for _, product := range products {
+
+		m := make(fiber.Map, len(fields))
+
+		for _, field := range fields {
+			switch field {
+			case "id":
+				m[field] = product.ID
+			case "name":
+				m[field] = product.Name
+			case "price":
+				m[field] = product.Price
+			case "stock":
+				m[field] = product.Stock
+			case "note":
+				m[field] = product.Note
+			case "quantity":
+				m[field] = product.Quantity
+			case "priority":
+				m[field] = product.Priority
+			case "variant":
+				m[field] = product.Variant
+			}
+		}
+
+		sparse = append(sparse, m)
+	}
+
+	return sparse
+}
+
+// sparseLists listeleri, içindeki ürünleri yalnızca istenen alanlarla olacak şekilde döner.
+func sparseLists(lists []models.FavoriteListResponse, fields []string) []fiber.Map {
+
+	sparse := make([]fiber.Map, 0, len(lists))
+
+	for _, list := range lists {
+
+		m := fiber.Map{
+			"list_id":   list.ListId,
+			"list_name": list.ListName,
+		}
+
+		if list.ItemIds != nil {
+			m["item_ids"] = list.ItemIds
+		}
+
+		if list.ItemCount != nil {
+			m["item_count"] = *list.ItemCount
+		}
+
+		if list.Items != nil {
+			m["products"] = sparseProducts(list.Items, fields)
+		}
+
+		sparse = append(sparse, m)
+	}
+
+	return sparse
+}
+
+// sparseListDetail liste detayını, ürünleri yalnızca istenen alanlarla olacak şekilde döner.
+func sparseListDetail(detail models.FavoriteListDetail, fields []string) fiber.Map {
+
+	return fiber.Map{
+		"list_id":         detail.ListId,
+		"list_name":       detail.ListName,
+		"description":     detail.Description,
+		"visibility":      detail.Visibility,
+		"list_type":       detail.ListType,
+		"emoji":           detail.Emoji,
+		"cover_image_url": detail.CoverImageUrl,
+		"created_date":    detail.CreatedDate,
+		"updated_date":    detail.UpdatedDate,
+		"user_id":         detail.UserId,
+		"is_default":      detail.IsDefault,
+		"version":         detail.Version,
+		"item_count":      detail.ItemCount,
+		"products":        sparseProducts(detail.Items, fields),
+	}
+}
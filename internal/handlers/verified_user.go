@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"favorite_service/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// verifiedUserLocal, RateLimit'in doğruladığı kullanıcının c.Locals anahtarıdır.
+const verifiedUserLocal = "verifiedUser"
+
+type verifiedUserKey struct{}
+
+type verifiedUser struct {
+	token string
+	user  *models.Users
+}
+
+func withVerifiedUser(ctx context.Context, token string, user *models.Users) context.Context {
+	return context.WithValue(ctx, verifiedUserKey{}, verifiedUser{token: token, user: user})
+}
+
+// verifiedUserFromContext yalnızca context'teki kullanıcı aynı token ile doğrulanmışsa döner.
+func verifiedUserFromContext(ctx context.Context, token string) (*models.Users, bool) {
+
+	entry, ok := ctx.Value(verifiedUserKey{}).(verifiedUser)
+	if !ok || entry.user == nil || entry.token != token {
+		return nil, false
+	}
+
+	return entry.user, true
+}
+
+// VerifiedUser, istek için RateLimit tarafından doğrulanmış kullanıcıyı döner.
+func VerifiedUser(c *fiber.Ctx) (*models.Users, bool) {
+
+	user, ok := c.Locals(verifiedUserLocal).(*models.Users)
+
+	return user, ok && user != nil
+}
+
+// ContextUserClient, istek context'inde aynı token için doğrulanmış kullanıcı varsa onu döner;
+// yoksa isteği sarmaladığı user client'a iletir. Böylece RateLimit'in doğruladığı token
+// servis katmanında tekrar user servisine gönderilmez.
+type ContextUserClient struct {
+	next rateLimitUserClient
+}
+
+func NewContextUserClient(next rateLimitUserClient) *ContextUserClient {
+
+	return &ContextUserClient{next: next}
+}
+
+func (c *ContextUserClient) VerifyUser(token string, ctx context.Context) (*models.Users, error) {
+
+	if user, ok := verifiedUserFromContext(ctx, token); ok {
+		return user, nil
+	}
+
+	return c.next.VerifyUser(token, ctx)
+}
@@ -0,0 +1,263 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"favorite_service/internal/models"
+	"favorite_service/logs"
+	"fmt"
+
+	"github.com/go-swagno/swagno/components/endpoint"
+	"github.com/go-swagno/swagno/components/http/response"
+	"github.com/go-swagno/swagno/components/parameter"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	defaultDeliveryLimit = 50
+	maxDeliveryLimit     = 500
+)
+
+type webhookService interface {
+	CreateSubscription(ctx context.Context, request models.CreateWebhookSubscription) (models.WebhookSubscriptionResponse, error)
+	GetSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, subscriptionId int) error
+	GetDeliveries(ctx context.Context, subscriptionId int, limit int) ([]models.WebhookDelivery, error)
+}
+
+type WebhookHandler struct {
+	webhookService webhookService
+	adminToken     string
+}
+
+func NewWebhookHandler(webhookService webhookService, adminToken string) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+		adminToken:     adminToken,
+	}
+}
+
+func (h *WebhookHandler) CreateSubscriptionHandle(c *fiber.Ctx) error {
+
+	request := models.CreateWebhookSubscription{}
+
+	if err := c.BodyParser(&request); err != nil {
+
+		logs.Error(err.Error(),
+			logs.WithHandlerName("WebhookHandler_CreateSubscription"),
+			logs.WithStatus(fiber.StatusBadRequest),
+		)
+
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Body Parse Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	if err := request.Validate(); err != nil {
+
+		logs.Warning(err.Error(),
+			logs.WithHandlerName("WebhookHandler_CreateSubscription"),
+			logs.WithStatus(fiber.StatusBadRequest),
+		)
+
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Validate Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	ctx := c.UserContext()
+
+	subscription, err := h.webhookService.CreateSubscription(ctx, request)
+
+	if err != nil {
+
+		logs.Error(err.Error(),
+			logs.WithHandlerName("WebhookHandler_CreateSubscription"),
+			logs.WithStatus(fiber.StatusInternalServerError),
+		)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErorResponse{
+			Error:   "Servis Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	logs.Info("Webhook Aboneliği Oluşturuldu",
+		logs.WithHandlerName("WebhookHandler_CreateSubscription"),
+		logs.WithStatus(fiber.StatusCreated),
+	)
+
+	return c.Status(fiber.StatusCreated).JSON(models.SuccesResponse{SuccesData: subscription})
+
+}
+
+func (h *WebhookHandler) GetSubscriptionsHandle(c *fiber.Ctx) error {
+
+	ctx := c.UserContext()
+
+	subscriptions, err := h.webhookService.GetSubscriptions(ctx)
+
+	if err != nil {
+
+		logs.Error(err.Error(),
+			logs.WithHandlerName("WebhookHandler_GetSubscriptions"),
+			logs.WithStatus(fiber.StatusInternalServerError),
+		)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErorResponse{
+			Error:   "Servis Hatasi",
+			Details: err.Error()},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(models.SuccesResponse{SuccesData: subscriptions})
+
+}
+
+func (h *WebhookHandler) DeleteSubscriptionHandle(c *fiber.Ctx) error {
+
+	subscriptionId, err := c.ParamsInt("subscriptionId")
+
+	if err != nil {
+
+		logs.Warning(err.Error(),
+			logs.WithHandlerName("WebhookHandler_DeleteSubscription"),
+			logs.WithStatus(fiber.StatusBadRequest),
+		)
+
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Subscription Id Bulunamadi",
+			Details: err.Error()},
+		)
+	}
+
+	ctx := c.UserContext()
+
+	if err := h.webhookService.DeleteSubscription(ctx, subscriptionId); err != nil {
+		return h.serviceError(c, "WebhookHandler_DeleteSubscription", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(models.SuccesResponse{SuccesData: "Silindi"})
+
+}
+
+func (h *WebhookHandler) GetDeliveriesHandle(c *fiber.Ctx) error {
+
+	subscriptionId, err := c.ParamsInt("subscriptionId")
+
+	if err != nil {
+
+		logs.Warning(err.Error(),
+			logs.WithHandlerName("WebhookHandler_GetDeliveries"),
+			logs.WithStatus(fiber.StatusBadRequest),
+		)
+
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Subscription Id Bulunamadi",
+			Details: err.Error()},
+		)
+	}
+
+	limit := c.QueryInt("limit", defaultDeliveryLimit)
+
+	if limit < 1 || limit > maxDeliveryLimit {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErorResponse{
+			Error:   "Limit Parametre Hatasi",
+			Details: fmt.Sprintf("limit 1-%d aralığında olmalı", maxDeliveryLimit)},
+		)
+	}
+
+	ctx := c.UserContext()
+
+	deliveries, err := h.webhookService.GetDeliveries(ctx, subscriptionId, limit)
+
+	if err != nil {
+		return h.serviceError(c, "WebhookHandler_GetDeliveries", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(models.SuccesResponse{SuccesData: deliveries})
+
+}
+
+func (h *WebhookHandler) serviceError(c *fiber.Ctx, handlerName string, err error) error {
+
+	if errors.Is(err, models.ErrRecordNotFound) {
+
+		logs.Warning(err.Error(),
+			logs.WithHandlerName(handlerName),
+			logs.WithStatus(fiber.StatusNotFound),
+		)
+
+		return c.Status(fiber.StatusNotFound).JSON(models.ErorResponse{
+			Error:   "Abonelik Bulunamadi",
+			Details: err.Error()},
+		)
+	}
+
+	logs.Error(err.Error(),
+		logs.WithHandlerName(handlerName),
+		logs.WithStatus(fiber.StatusInternalServerError),
+	)
+
+	return c.Status(fiber.StatusInternalServerError).JSON(models.ErorResponse{
+		Error:   "Servis Hatasi",
+		Details: err.Error()},
+	)
+}
+
+func (h *WebhookHandler) SetRoutes(app *fiber.App) {
+
+	webhookGroup := app.Group("/admin/webhooks", RequireAdminToken(h.adminToken))
+
+	webhookGroup.Post("", h.CreateSubscriptionHandle)
+	webhookGroup.Get("/", h.GetSubscriptionsHandle)
+	webhookGroup.Delete("/:subscriptionId", h.DeleteSubscriptionHandle)
+	webhookGroup.Get("/:subscriptionId/deliveries", h.GetDeliveriesHandle)
+
+}
+
+func WebhookGetEndpoints() []*endpoint.EndPoint {
+	return []*endpoint.EndPoint{
+		endpoint.New(
+			endpoint.POST,
+			"/admin/webhooks",
+			endpoint.WithTags("webhooks"),
+			endpoint.WithParams(parameter.StrParam(adminTokenHeader, parameter.Header, parameter.WithRequired())),
+			endpoint.WithBody(models.CreateWebhookSubscription{}),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New(models.WebhookSubscriptionResponse{}, "201", "Created")}),
+			endpoint.WithErrors([]response.Response{response.New(models.ErorResponse{}, "400", "Bad Request")}),
+		),
+
+		endpoint.New(
+			endpoint.GET,
+			"/admin/webhooks",
+			endpoint.WithTags("webhooks"),
+			endpoint.WithParams(parameter.StrParam(adminTokenHeader, parameter.Header, parameter.WithRequired())),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New([]models.WebhookSubscription{}, "200", "OK")}),
+			endpoint.WithErrors([]response.Response{response.New(models.ErorResponse{}, "401", "Unauthorized")}),
+		),
+
+		endpoint.New(
+			endpoint.DELETE,
+			"/admin/webhooks/{subscriptionId}",
+			endpoint.WithTags("webhooks"),
+			endpoint.WithParams(parameter.IntParam("subscriptionId", parameter.Path, parameter.WithRequired())),
+			endpoint.WithParams(parameter.StrParam(adminTokenHeader, parameter.Header, parameter.WithRequired())),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New(models.SuccesResponse{}, "200", "OK")}),
+			endpoint.WithErrors([]response.Response{response.New(models.ErorResponse{}, "404", "Not Found")}),
+		),
+
+		endpoint.New(
+			endpoint.GET,
+			"/admin/webhooks/{subscriptionId}/deliveries",
+			endpoint.WithTags("webhooks"),
+			endpoint.WithParams(parameter.IntParam("subscriptionId", parameter.Path, parameter.WithRequired())),
+			endpoint.WithParams(parameter.IntParam("limit", parameter.Query, parameter.WithDefault(defaultDeliveryLimit))),
+			endpoint.WithParams(parameter.StrParam(adminTokenHeader, parameter.Header, parameter.WithRequired())),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New([]models.WebhookDelivery{}, "200", "OK")}),
+			endpoint.WithErrors([]response.Response{response.New(models.ErorResponse{}, "404", "Not Found")}),
+		),
+	}
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const (
+	AuditEntityList = "list"
+	AuditEntityItem = "item"
+)
+
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+	AuditActionMove   = "move"
+)
+
+// AuditEntry liste veya ürün üzerindeki tek bir değişikliğin kaydıdır. Before ve After
+// değişiklikten önceki ve sonraki durumun JSON görüntüsüdür; oluşturmada Before, silmede After boştur.
+type AuditEntry struct {
+	Id          int64           `json:"id" gorm:"column:id;primaryKey;autoIncrement"`
+	ActorUserId int             `json:"actor_user_id" gorm:"column:actoruserid"`
+	RequestId   string          `json:"request_id" gorm:"column:requestid"`
+	EntityType  string          `json:"entity_type" gorm:"column:entitytype"`
+	Action      string          `json:"action" gorm:"column:action"`
+	ListId      int             `json:"list_id" gorm:"column:listid"`
+	ItemId      *int            `json:"item_id,omitempty" gorm:"column:itemid"`
+	Before      json.RawMessage `json:"before,omitempty" gorm:"column:before"`
+	After       json.RawMessage `json:"after,omitempty" gorm:"column:after"`
+	CreatedDate time.Time       `json:"created_date" gorm:"column:createddate;default:now()"`
+}
+
+// AuditListSnapshot liste silinirken listenin ürünleriyle birlikte saklanan görüntüsüdür.
+type AuditListSnapshot struct {
+	List  FavoriteList   `json:"list"`
+	Items []FavoriteItem `json:"items"`
+}
+
+// AuditLogFilter admin audit sorgusudur. ListId veya UserId'den en az biri verilmelidir.
+// Sonuçlar yeniden eskiye sıralanır; Cursor bir önceki sayfanın NextCursor değeridir.
+type AuditLogFilter struct {
+	ListId int
+	UserId int
+	From   *time.Time
+	To     *time.Time
+	Cursor int64
+	Limit  int
+}
+
+func (a AuditLogFilter) Validate() error {
+	return validation.ValidateStruct(&a,
+		validation.Field(&a.ListId, validation.Required.When(a.UserId == 0).Error("listId veya userId zorunlu"), validation.Min(0)),
+		validation.Field(&a.UserId, validation.Min(0)),
+		validation.Field(&a.Cursor, validation.Min(int64(0))),
+		validation.Field(&a.To, validation.By(func(value interface{}) error {
+			if a.From != nil && a.To != nil && a.To.Before(*a.From) {
+				return validation.NewError("validation_audit_range", "to, from'dan önce olamaz")
+			}
+			return nil
+		})),
+	)
+}
+
+type AuditLogPage struct {
+	Entries    []AuditEntry `json:"entries"`
+	NextCursor *int64       `json:"next_cursor,omitempty"`
+}
@@ -11,3 +11,11 @@ var ErrunaUthorizedAction error = errors.New("Kullanıcı Sadece Kendi Listesine
 var ErrUserUnauthorized error = errors.New("Kullanici Bulunamadi")
 
 var ErrKeyIsEmpty error = errors.New("Key Boş")
+
+var ErrDuplicateItem error = errors.New("Aynı ürün birden fazla kez gönderilemez")
+
+var ErrVersionMismatch error = errors.New("Liste baska bir istek tarafindan degistirildi")
+
+var ErrProductNotFound error = errors.New("Ürün bulunamadı")
+
+var ErrProductServiceUnavailable error = errors.New("Ürün servisine ulaşılamadı")
@@ -7,14 +7,48 @@ import (
 )
 
 type FavoriteItem struct {
-	ItemId      int       `json:"item_id" gorm:"column:itemid"`
-	ListId      int       `json:"list_id" gorm:"column:listid"`
-	CreatedDate time.Time `json:"created_date" gorm:"column:createddate;default:now()"`
+	ItemId         int       `json:"item_id" gorm:"column:itemid"`
+	ListId         int       `json:"list_id" gorm:"column:listid"`
+	CreatedDate    time.Time `json:"created_date" gorm:"column:createddate;default:now()"`
+	FavoritedPrice *float64  `json:"favorited_price,omitempty" gorm:"column:favoritedprice"`
+	FavoritedStock *int      `json:"favorited_stock,omitempty" gorm:"column:favoritedstock"`
+	Position       int       `json:"position" gorm:"column:position"`
+	Note           string    `json:"note" gorm:"column:note"`
+	Quantity       int       `json:"quantity" gorm:"column:quantity;default:1"`
+	Priority       string    `json:"priority" gorm:"column:priority;default:nice_to_have"`
+	Variant        string    `json:"variant" gorm:"column:variant"`
+}
+
+const (
+	PriorityMustHave   = "must_have"
+	PriorityNiceToHave = "nice_to_have"
+)
+
+// FavoriteProduct product servisinden gelen ürün bilgisini kullanıcının ürüne eklediği
+// not, adet, öncelik ve varyant bilgisiyle birlikte döner.
+type FavoriteProduct struct {
+	Product
+	Note     string `json:"note"`
+	Quantity int    `json:"quantity"`
+	Priority string `json:"priority"`
+	Variant  string `json:"variant"`
 }
 
 type CreateFavoriteItem struct {
-	ItemId int `json:"item_id"`
-	ListId int `json:"list_id"`
+	ItemId   int    `json:"item_id"`
+	ListId   int    `json:"list_id"`
+	Note     string `json:"note"`
+	Quantity int    `json:"quantity"`
+	Priority string `json:"priority"`
+	Variant  string `json:"variant"`
+}
+
+// PatchFavoriteItem yalnızca gönderilen alanları günceller.
+type PatchFavoriteItem struct {
+	Note     *string `json:"note"`
+	Quantity *int    `json:"quantity"`
+	Priority *string `json:"priority"`
+	Variant  *string `json:"variant"`
 }
 
 type UpdateFavoriteItem struct {
@@ -24,10 +58,77 @@ type UpdateFavoriteItem struct {
 func (a CreateFavoriteItem) Validate() error {
 	return validation.ValidateStruct(&a,
 		validation.Field(&a.ItemId, validation.Required),
-		validation.Field(&a.ListId, validation.Required))
+		validation.Field(&a.ListId, validation.Min(0)),
+		validation.Field(&a.Note, validation.Length(0, 500)),
+		validation.Field(&a.Quantity, validation.Min(0), validation.Max(99)),
+		validation.Field(&a.Priority, validation.In(PriorityMustHave, PriorityNiceToHave)),
+		validation.Field(&a.Variant, validation.Length(0, 100)))
+}
+
+func (a PatchFavoriteItem) Validate() error {
+
+	if a.Note == nil && a.Quantity == nil && a.Priority == nil && a.Variant == nil {
+		return validation.NewError("validation_patch_empty", "en az bir alan gönderilmeli")
+	}
+
+	return validation.ValidateStruct(&a,
+		validation.Field(&a.Note, validation.Length(0, 500)),
+		validation.Field(&a.Quantity, validation.NilOrNotEmpty, validation.Min(1), validation.Max(99)),
+		validation.Field(&a.Priority, validation.NilOrNotEmpty, validation.In(PriorityMustHave, PriorityNiceToHave)),
+		validation.Field(&a.Variant, validation.Length(0, 100)))
 }
 
 func (a UpdateFavoriteItem) Validate() error {
 	return validation.ValidateStruct(&a,
 		validation.Field(&a.ListId, validation.Required))
 }
+
+type FavoriteItemMembership struct {
+	ItemId   int    `json:"item_id" gorm:"column:itemid"`
+	ListId   int    `json:"list_id" gorm:"column:listid"`
+	ListName string `json:"list_name" gorm:"column:listname"`
+}
+
+type FavoriteListRef struct {
+	ListId   int    `json:"list_id"`
+	ListName string `json:"list_name"`
+}
+
+type FavoriteContainsResponse struct {
+	ProductId int               `json:"product_id"`
+	Favorited bool              `json:"favorited"`
+	Lists     []FavoriteListRef `json:"lists"`
+}
+
+type AddListItem struct {
+	ItemId int `json:"item_id"`
+}
+
+func (a AddListItem) Validate() error {
+	return validation.ValidateStruct(&a,
+		validation.Field(&a.ItemId, validation.Required, validation.Min(1)))
+}
+
+type FavoriteItemsResponse struct {
+	ListId    int               `json:"list_id"`
+	ItemIds   []int             `json:"item_ids,omitempty"`
+	ItemCount *int              `json:"item_count,omitempty"`
+	Products  []FavoriteProduct `json:"products,omitempty"`
+}
+
+// ReorderFavoriteItems listedeki ürünlerin yeni sırasıdır. Yalnızca bir kısmı verilirse
+// verilen ürünler başa alınır, kalanlar mevcut sıralarını korur.
+type ReorderFavoriteItems struct {
+	ItemIds []int `json:"item_ids"`
+}
+
+func (r ReorderFavoriteItems) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.ItemIds, validation.Required, validation.Each(validation.Min(1))))
+}
+
+type FavoriteToggleResponse struct {
+	ProductId int  `json:"product_id"`
+	ListId    int  `json:"list_id"`
+	Favorited bool `json:"favorited"`
+}
@@ -1,37 +1,140 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
 )
 
+const (
+	VisibilityPrivate = "private"
+	VisibilityShared  = "shared"
+	VisibilityPublic  = "public"
+)
+
+const (
+	ListTypeWishlist     = "wishlist"
+	ListTypeGiftRegistry = "gift_registry"
+	ListTypeCollection   = "collection"
+)
+
+// FavoriteListMetadata listenin ad dışındaki tanımlayıcı bilgileridir.
+type FavoriteListMetadata struct {
+	Description   string `json:"description" gorm:"column:description"`
+	Visibility    string `json:"visibility" gorm:"column:visibility;default:private"`
+	ListType      string `json:"list_type" gorm:"column:listtype;default:wishlist"`
+	Emoji         string `json:"emoji" gorm:"column:emoji"`
+	CoverImageUrl string `json:"cover_image_url" gorm:"column:coverimageurl"`
+}
+
 type FavoriteList struct {
-	Id          int       `json:"list_id" gorm:"autoIncrement;column:id"`
-	ListName    string    `json:"list_name" gorm:"column:listname"`
+	Id       int    `json:"list_id" gorm:"autoIncrement;column:id"`
+	ListName string `json:"list_name" gorm:"column:listname"`
+	FavoriteListMetadata
 	CreatedDate time.Time `json:"created_date" gorm:"column:createddate;default:now()"`
+	UpdatedDate time.Time `json:"updated_date" gorm:"column:updateddate;default:now()"`
 	UserId      int       `json:"user_id" gorm:"column:userid"`
+	IsDefault   bool      `json:"is_default" gorm:"column:isdefault"`
+	Version     int       `json:"version" gorm:"column:version;default:1"`
 }
 
+// DefaultListName kullanıcının varsayılan listesi ilk kez oluşturulurken verilen isimdir.
+const DefaultListName = "Favorilerim"
+
 type FavoriteListResponse struct {
-	ListId   int       `json:"list_id"`
-	ListName string    `json:"list_name"`
-	Items    []Product `json:"products"`
+	ListId   int    `json:"list_id"`
+	ListName string `json:"list_name"`
+	FavoriteListMetadata
+	UpdatedDate time.Time         `json:"updated_date"`
+	IsDefault   bool              `json:"is_default"`
+	Version     int               `json:"version"`
+	ItemIds     []int             `json:"item_ids,omitempty"`
+	ItemCount   *int              `json:"item_count,omitempty"`
+	Items       []FavoriteProduct `json:"products,omitempty"`
+}
+
+// MarshalJSON ürünler istendiğinde products anahtarını boş liste için de yazar; eski GET /lists
+// istemcileri anahtarın her zaman gelmesini bekler. Yalnızca item_ids veya sayı istendiğinde
+// Items nil kalır ve anahtar yazılmaz.
+func (r FavoriteListResponse) MarshalJSON() ([]byte, error) {
+	type listResponse FavoriteListResponse
+
+	if r.Items == nil {
+		return json.Marshal(listResponse(r))
+	}
+
+	return json.Marshal(struct {
+		listResponse
+		Items []FavoriteProduct `json:"products"`
+	}{listResponse(r), r.Items})
+}
+
+type FavoriteListDetail struct {
+	ListId   int    `json:"list_id"`
+	ListName string `json:"list_name"`
+	FavoriteListMetadata
+	CreatedDate time.Time         `json:"created_date"`
+	UpdatedDate time.Time         `json:"updated_date"`
+	UserId      int               `json:"user_id"`
+	IsDefault   bool              `json:"is_default"`
+	Version     int               `json:"version"`
+	ItemCount   int               `json:"item_count"`
+	Items       []FavoriteProduct `json:"products,omitempty"`
+}
+
+// FavoriteListFilter GET /lists için tür ve görünürlük filtresidir. Boş alanlar filtrelenmez.
+type FavoriteListFilter struct {
+	ListType   string
+	Visibility string
 }
 
 type CreateFavoriteList struct {
 	ListName string `json:"list_name"`
 	UserId   int    `json:"user_id"`
+	FavoriteListMetadata
 }
 
 type UpdateFavoriteList struct {
 	ListName string `json:"list_name"`
+	FavoriteListMetadata
+}
+
+// PatchFavoriteList yalnızca gönderilen alanları günceller.
+type PatchFavoriteList struct {
+	ListName      *string `json:"list_name"`
+	Description   *string `json:"description"`
+	Visibility    *string `json:"visibility"`
+	ListType      *string `json:"list_type"`
+	Emoji         *string `json:"emoji"`
+	CoverImageUrl *string `json:"cover_image_url"`
+}
+
+func (m FavoriteListMetadata) Validate() error {
+
+	return validation.ValidateStruct(&m,
+		validation.Field(&m.Description, validation.Length(0, 500)),
+		validation.Field(&m.Visibility, validation.In(VisibilityPrivate, VisibilityShared, VisibilityPublic)),
+		validation.Field(&m.ListType, validation.In(ListTypeWishlist, ListTypeGiftRegistry, ListTypeCollection)),
+		validation.Field(&m.Emoji, validation.RuneLength(0, 4)),
+		validation.Field(&m.CoverImageUrl, validation.Length(0, 500), is.URL))
+
+}
+
+func (f FavoriteListFilter) Validate() error {
+
+	return validation.ValidateStruct(&f,
+		validation.Field(&f.Visibility, validation.In(VisibilityPrivate, VisibilityShared, VisibilityPublic)),
+		validation.Field(&f.ListType, validation.In(ListTypeWishlist, ListTypeGiftRegistry, ListTypeCollection)))
+
 }
 
 func (a CreateFavoriteList) Validate() error {
 
 	return validation.ValidateStruct(&a,
-		validation.Field(&a.ListName, validation.Required.Error("İsim alanı Zorunlu"), validation.Length(2, 100).Error("İsim 2-100 aralığında olmalı")))
+		validation.Field(&a.ListName, validation.Required.Error("İsim alanı Zorunlu"), validation.Length(2, 100).Error("İsim 2-100 aralığında olmalı")),
+		validation.Field(&a.FavoriteListMetadata))
 	//validation.Field(&a.UserId, validation.Required))
 
 }
@@ -39,6 +142,24 @@ func (a CreateFavoriteList) Validate() error {
 func (a UpdateFavoriteList) Validate() error {
 
 	return validation.ValidateStruct(&a,
-		validation.Field(&a.ListName, validation.Required, validation.Length(2, 100)))
+		validation.Field(&a.ListName, validation.Required, validation.Length(2, 100)),
+		validation.Field(&a.FavoriteListMetadata))
+
+}
+
+func (a PatchFavoriteList) Validate() error {
+
+	if a.ListName == nil && a.Description == nil && a.Visibility == nil &&
+		a.ListType == nil && a.Emoji == nil && a.CoverImageUrl == nil {
+		return validation.NewError("validation_patch_empty", "en az bir alan gönderilmeli")
+	}
+
+	return validation.ValidateStruct(&a,
+		validation.Field(&a.ListName, validation.NilOrNotEmpty, validation.Length(2, 100)),
+		validation.Field(&a.Description, validation.Length(0, 500)),
+		validation.Field(&a.Visibility, validation.NilOrNotEmpty, validation.In(VisibilityPrivate, VisibilityShared, VisibilityPublic)),
+		validation.Field(&a.ListType, validation.NilOrNotEmpty, validation.In(ListTypeWishlist, ListTypeGiftRegistry, ListTypeCollection)),
+		validation.Field(&a.Emoji, validation.RuneLength(0, 4)),
+		validation.Field(&a.CoverImageUrl, validation.Length(0, 500), is.URL))
 
 }
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	IdempotencyStatusInProgress = "in_progress"
+	IdempotencyStatusCompleted  = "completed"
+)
+
+var ErrIdempotencyKeyReused error = errors.New("Idempotency-Key farkli bir istek icin kullanilmis")
+
+var ErrIdempotencyInProgress error = errors.New("Ayni Idempotency-Key ile gelen istek hala isleniyor")
+
+// IdempotencyRecord, Scope (istemci) ve IdempotencyKey ile tekil olan bir yazma isteğinin
+// parmak izini ve tamamlandıysa saklanan yanıtını tutar.
+type IdempotencyRecord struct {
+	Scope          string    `json:"scope" gorm:"column:scope;primaryKey"`
+	IdempotencyKey string    `json:"idempotency_key" gorm:"column:idempotencykey;primaryKey"`
+	Fingerprint    string    `json:"fingerprint" gorm:"column:fingerprint"`
+	Status         string    `json:"status" gorm:"column:status"`
+	ResponseStatus *int      `json:"response_status,omitempty" gorm:"column:responsestatus"`
+	ContentType    *string   `json:"content_type,omitempty" gorm:"column:contenttype"`
+	ResponseBody   []byte    `json:"response_body,omitempty" gorm:"column:responsebody"`
+	CreatedDate    time.Time `json:"created_date" gorm:"column:createddate"`
+	ExpiresAt      time.Time `json:"expires_at" gorm:"column:expiresat"`
+}
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const (
+	ImportRowStatusCreated   = "created"
+	ImportRowStatusDuplicate = "duplicate"
+	ImportRowStatusInvalid   = "invalid"
+)
+
+// MaxImportRows tek bir içe aktarım isteğinde kabul edilen en fazla satır sayısıdır.
+const MaxImportRows = 1000
+
+// MaxImportProducts tek bir içe aktarımda product servisine doğrulatılan en fazla farklı ürün sayısıdır.
+const MaxImportProducts = 200
+
+var ErrImportHasInvalidRows error = errors.New("İçe aktarımda geçersiz satırlar var, hiçbir kayıt oluşturulmadı")
+
+var ErrImportTooManyProducts error = fmt.Errorf("Tek bir içe aktarımda en fazla %d farklı ürün olabilir", MaxImportProducts)
+
+var ErrImportTooManyRows error = fmt.Errorf("Tek bir içe aktarımda en fazla %d satır olabilir", MaxImportRows)
+
+var ErrImportSameAccount error = errors.New("Kaynak hesap içe aktarılan hesapla aynı olamaz")
+
+// ImportRow içe aktarılan dosyadaki tek bir satırdır. ItemId 0 ise satır yalnızca listeyi oluşturur.
+type ImportRow struct {
+	Row      int    `json:"row"`
+	ListName string `json:"list_name"`
+	ItemId   int    `json:"item_id"`
+}
+
+func (r ImportRow) Validate() error {
+
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.ListName, validation.Required.Error("İsim alanı Zorunlu"), validation.Length(2, 100).Error("İsim 2-100 aralığında olmalı")),
+		validation.Field(&r.ItemId, validation.Min(0).Error("item_id negatif olamaz")))
+
+}
+
+type ImportList struct {
+	ListName string `json:"list_name"`
+	ItemIds  []int  `json:"item_ids"`
+}
+
+type ImportRequest struct {
+	Lists []ImportList `json:"lists"`
+}
+
+// Rows JSON isteğini CSV ile aynı satır yapısına çevirir. Ürünü olmayan liste tek satır olur.
+func (r ImportRequest) Rows() []ImportRow {
+
+	rows := make([]ImportRow, 0, len(r.Lists))
+
+	for _, list := range r.Lists {
+
+		if len(list.ItemIds) == 0 {
+			rows = append(rows, ImportRow{Row: len(rows) + 1, ListName: list.ListName})
+			continue
+		}
+
+		for _, itemId := range list.ItemIds {
+			rows = append(rows, ImportRow{Row: len(rows) + 1, ListName: list.ListName, ItemId: itemId})
+		}
+	}
+
+	return rows
+}
+
+type ImportRowResult struct {
+	ImportRow
+	ListId  int    `json:"list_id,omitempty"`
+	NewList bool   `json:"new_list"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ImportReport dry-run modunda oluşturulacak, commit modunda oluşturulan kayıtları satır satır döner.
+type ImportReport struct {
+	DryRun       bool              `json:"dry_run"`
+	Committed    bool              `json:"committed"`
+	ListsCreated int               `json:"lists_created"`
+	ItemsCreated int               `json:"items_created"`
+	Duplicates   int               `json:"duplicates"`
+	Invalid      int               `json:"invalid"`
+	Rows         []ImportRowResult `json:"rows"`
+}
+
+type ImportErrorResponse struct {
+	Error   string       `json:"error"`
+	Details string       `json:"details"`
+	Report  ImportReport `json:"report"`
+}
+
+// AccountImportRequest başka bir hesabın listelerini içe aktarmak için kullanılır. SourceToken kaynak
+// hesabın token'ıdır; istemcinin o hesaba da sahip olduğunu kanıtlar.
+type AccountImportRequest struct {
+	SourceToken string `json:"source_token"`
+}
@@ -0,0 +1,18 @@
+package models
+
+const (
+	IncludeItems    = "items"
+	IncludeCounts   = "counts"
+	IncludeProducts = "products"
+)
+
+// ResponseInclude liste ve ürün yanıtlarında hangi parçaların doldurulacağını belirtir.
+// Products istenmediğinde product servisine hiç gidilmez.
+type ResponseInclude struct {
+	ItemIds  bool
+	Counts   bool
+	Products bool
+}
+
+// ProductFields ?fields= ile seçilebilecek ürün alanlarıdır.
+var ProductFields = []string{"id", "name", "price", "stock", "note", "quantity", "priority", "variant"}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+type OutboxEvent struct {
+	Id            string     `json:"id" gorm:"column:id;primaryKey"`
+	EventType     string     `json:"event_type" gorm:"column:eventtype"`
+	Version       int        `json:"version" gorm:"column:version"`
+	Payload       string     `json:"payload" gorm:"column:payload"`
+	CreatedDate   time.Time  `json:"created_date" gorm:"column:createddate;default:now()"`
+	PublishedDate *time.Time `json:"published_date,omitempty" gorm:"column:publisheddate"`
+	Attempts      int        `json:"attempts" gorm:"column:attempts"`
+	LastError     *string    `json:"last_error,omitempty" gorm:"column:lasterror"`
+
+	NextAttemptDate time.Time  `json:"next_attempt_date" gorm:"column:nextattemptdate;default:now()"`
+	DeadLetterDate  *time.Time `json:"dead_letter_date,omitempty" gorm:"column:deadletterdate"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+const (
+	ExportFormatJSON = "json"
+	ExportFormatCSV  = "csv"
+)
+
+// UserDataExport kullanıcının tüm listelerinin ve ürünlerinin makine tarafından okunabilir dökümüdür.
+type UserDataExport struct {
+	UserId     int            `json:"user_id"`
+	ExportedAt time.Time      `json:"exported_at"`
+	Lists      []ExportedList `json:"lists"`
+}
+
+type ExportedList struct {
+	FavoriteList
+	Items []FavoriteItem `json:"items"`
+}
+
+// ErasureResult silme isteği sonucunda etkilenen kayıt sayılarıdır. Lists ve Items silinen kayıtların
+// kendisidir; silme olaylarını yazmak için kullanılır, yanıta eklenmez.
+type ErasureResult struct {
+	UserId                  int            `json:"user_id"`
+	ListsDeleted            int64          `json:"lists_deleted"`
+	ItemsDeleted            int64          `json:"items_deleted"`
+	AlertsDeleted           int64          `json:"alerts_deleted"`
+	AuditEntriesAnonymized  int64          `json:"audit_entries_anonymized"`
+	EventsPurged            int64          `json:"events_purged"`
+	WebhookDeliveriesPurged int64          `json:"webhook_deliveries_purged"`
+	IdempotencyKeysPurged   int64          `json:"idempotency_keys_purged"`
+	Lists                   []FavoriteList `json:"-"`
+	Items                   []FavoriteItem `json:"-"`
+}
@@ -0,0 +1,14 @@
+package models
+
+// Problem RFC 7807 "application/problem+json" hata gövdesidir.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+type DataResponse struct {
+	Data interface{} `json:"data"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+const (
+	AlertTypePriceDrop   = "price_drop"
+	AlertTypeBackInStock = "back_in_stock"
+)
+
+type ProductWatch struct {
+	ItemId      int       `json:"item_id" gorm:"column:itemid;primaryKey"`
+	LastPrice   float64   `json:"last_price" gorm:"column:lastprice"`
+	LastStock   int       `json:"last_stock" gorm:"column:laststock"`
+	CheckedDate time.Time `json:"checked_date" gorm:"column:checkeddate"`
+}
+
+type ProductAlert struct {
+	Id               int       `json:"alert_id" gorm:"autoIncrement;column:id"`
+	UserId           int       `json:"user_id" gorm:"column:userid"`
+	ListId           int       `json:"list_id" gorm:"column:listid"`
+	ItemId           int       `json:"item_id" gorm:"column:itemid"`
+	AlertType        string    `json:"alert_type" gorm:"column:alerttype"`
+	OldPrice         *float64  `json:"old_price,omitempty" gorm:"column:oldprice"`
+	NewPrice         *float64  `json:"new_price,omitempty" gorm:"column:newprice"`
+	OldStock         *int      `json:"old_stock,omitempty" gorm:"column:oldstock"`
+	NewStock         *int      `json:"new_stock,omitempty" gorm:"column:newstock"`
+	PriceDropPercent *float64  `json:"price_drop_percent,omitempty" gorm:"-"`
+	CreatedDate      time.Time `json:"created_date" gorm:"column:createddate;default:now()"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import "fmt"
+
+const (
+	QuotaResourceLists     = "lists"
+	QuotaResourceListItems = "list_items"
+)
+
+// QuotaLimits kullanıcı başına liste ve liste başına ürün sınırlarıdır. 0 sınırsız demektir.
+type QuotaLimits struct {
+	MaxListsPerUser int
+	MaxItemsPerList int
+}
+
+type QuotaExceededError struct {
+	Resource string
+	Used     int
+	Limit    int
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("%s kotası aşıldı: %d/%d", e.Resource, e.Used, e.Limit)
+}
+
+type QuotaUsage struct {
+	Used  int `json:"used"`
+	Limit int `json:"limit"`
+}
+
+type ListQuotaUsage struct {
+	ListId   int    `json:"list_id" gorm:"column:listid"`
+	ListName string `json:"list_name" gorm:"column:listname"`
+	Used     int    `json:"used" gorm:"column:used"`
+	Limit    int    `json:"limit" gorm:"-"`
+}
+
+type UserQuotaUsage struct {
+	Lists        QuotaUsage       `json:"lists"`
+	ItemsPerList []ListQuotaUsage `json:"items_per_list"`
+}
+
+type QuotaErrorResponse struct {
+	Error    string `json:"error"`
+	Details  string `json:"details"`
+	Resource string `json:"resource"`
+	Used     int    `json:"used"`
+	Limit    int    `json:"limit"`
+}
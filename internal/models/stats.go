@@ -0,0 +1,22 @@
+package models
+
+import validation "github.com/go-ozzo/ozzo-validation/v4"
+
+type ProductFavoriteCount struct {
+	ItemId        int   `json:"item_id" gorm:"column:itemid"`
+	FavoriteCount int64 `json:"favorite_count" gorm:"column:favoritecount"`
+}
+
+type PopularProduct struct {
+	Product       Product `json:"product"`
+	FavoriteCount int64   `json:"favorite_count"`
+}
+
+type FavoriteCountsRequest struct {
+	ProductIds []int `json:"product_ids"`
+}
+
+func (a FavoriteCountsRequest) Validate() error {
+	return validation.ValidateStruct(&a,
+		validation.Field(&a.ProductIds, validation.Required, validation.Length(1, 500), validation.Each(validation.Min(1))))
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"errors"
+	"favorite_service/internal/events"
+	"net/url"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryDead      = "dead"
+)
+
+type WebhookSubscription struct {
+	Id          int       `json:"subscription_id" gorm:"autoIncrement;column:id"`
+	Url         string    `json:"url" gorm:"column:url"`
+	EventTypes  string    `json:"event_types" gorm:"column:eventtypes"`
+	Secret      string    `json:"-" gorm:"column:secret"`
+	Active      bool      `json:"active" gorm:"column:active;default:true"`
+	CreatedDate time.Time `json:"created_date" gorm:"column:createddate;default:now()"`
+}
+
+type WebhookSubscriptionResponse struct {
+	WebhookSubscription
+	Secret string `json:"secret,omitempty"`
+}
+
+type CreateWebhookSubscription struct {
+	Url        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Secret     string   `json:"secret"`
+}
+
+type WebhookDelivery struct {
+	Id              int       `json:"delivery_id" gorm:"autoIncrement;column:id"`
+	SubscriptionId  int       `json:"subscription_id" gorm:"column:subscriptionid"`
+	EventId         string    `json:"event_id" gorm:"column:eventid"`
+	EventType       string    `json:"event_type" gorm:"column:eventtype"`
+	Payload         string    `json:"payload" gorm:"column:payload"`
+	Status          string    `json:"status" gorm:"column:status;default:pending"`
+	Attempts        int       `json:"attempts" gorm:"column:attempts"`
+	NextAttemptDate time.Time `json:"next_attempt_date" gorm:"column:nextattemptdate;default:now()"`
+	LastStatusCode  *int      `json:"last_status_code,omitempty" gorm:"column:laststatuscode"`
+	LastError       *string   `json:"last_error,omitempty" gorm:"column:lasterror"`
+	CreatedDate     time.Time `json:"created_date" gorm:"column:createddate;default:now()"`
+	UpdatedDate     time.Time `json:"updated_date" gorm:"column:updateddate;default:now()"`
+}
+
+func (s WebhookSubscription) EventTypeList() []string {
+	return strings.Split(s.EventTypes, ",")
+}
+
+func (a CreateWebhookSubscription) Validate() error {
+
+	allowed := make([]interface{}, len(events.Types))
+	for i, eventType := range events.Types {
+		allowed[i] = eventType
+	}
+
+	return validation.ValidateStruct(&a,
+		validation.Field(&a.Url, validation.Required, validation.Length(1, 2048), validation.By(validateWebhookURL)),
+		validation.Field(&a.EventTypes, validation.Required, validation.Each(validation.Required, validation.In(allowed...))),
+		validation.Field(&a.Secret, validation.Length(16, 255)))
+}
+
+func validateWebhookURL(value interface{}) error {
+
+	rawURL, _ := value.(string)
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return errors.New("geçerli bir http(s) url olmalı")
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"context"
+	"favorite_service/internal/models"
+	"favorite_service/pkg/psql"
+
+	"gorm.io/gorm"
+)
+
+type AuditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) *AuditRepository {
+	return &AuditRepository{
+		db: db,
+	}
+}
+
+// AddAuditEntry context'teki transaction'a katılır; kayıt yalnızca değişiklik commit edilirse yazılır.
+func (r *AuditRepository) AddAuditEntry(ctx context.Context, entry *models.AuditEntry) error {
+
+	return psql.Conn(ctx, r.db).Table("auditlog").Create(entry).Error
+
+}
+
+// GetAuditEntries filtreye uyan kayıtları id'ye göre yeniden eskiye, en fazla limit kadar döner.
+func (r *AuditRepository) GetAuditEntries(ctx context.Context, filter models.AuditLogFilter, limit int) ([]models.AuditEntry, error) {
+
+	var entries []models.AuditEntry
+
+	query := psql.Conn(ctx, r.db).Table("auditlog")
+
+	if filter.ListId != 0 {
+		query = query.Where("listid = ?", filter.ListId)
+	}
+
+	if filter.UserId != 0 {
+		query = query.Where("actoruserid = ?", filter.UserId)
+	}
+
+	if filter.From != nil {
+		query = query.Where("createddate >= ?", *filter.From)
+	}
+
+	if filter.To != nil {
+		query = query.Where("createddate < ?", *filter.To)
+	}
+
+	if filter.Cursor != 0 {
+		query = query.Where("id < ?", filter.Cursor)
+	}
+
+	if err := query.Order("id DESC").Limit(limit).Find(&entries).Error; err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+
+}
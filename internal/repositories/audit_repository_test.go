@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"encoding/json"
+	"favorite_service/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditRepository(t *testing.T) {
+
+	auditRepository := NewAuditRepository(db)
+
+	itemId := 6
+
+	t.Run("TestAddAuditEntry", func(t *testing.T) {
+
+		for _, action := range []string{models.AuditActionCreate, models.AuditActionUpdate, models.AuditActionDelete} {
+
+			entry := models.AuditEntry{
+				ActorUserId: 7,
+				RequestId:   "req-1",
+				EntityType:  models.AuditEntityItem,
+				Action:      action,
+				ListId:      70,
+				ItemId:      &itemId,
+				After:       json.RawMessage(`{"item_id":6}`),
+			}
+
+			assert.Nil(t, auditRepository.AddAuditEntry(ctx, &entry))
+
+			assert.NotZero(t, entry.Id)
+		}
+
+	})
+
+	t.Run("TestGetAuditEntries", func(t *testing.T) {
+
+		entries, err := auditRepository.GetAuditEntries(ctx, models.AuditLogFilter{ListId: 70}, 2)
+
+		assert.Nil(t, err)
+
+		assert.Len(t, entries, 2)
+
+		assert.Equal(t, models.AuditActionDelete, entries[0].Action)
+
+		assert.JSONEq(t, `{"item_id":6}`, string(entries[0].After))
+
+		assert.Nil(t, entries[0].Before)
+
+		older, err := auditRepository.GetAuditEntries(ctx, models.AuditLogFilter{ListId: 70, Cursor: entries[1].Id}, 2)
+
+		assert.Nil(t, err)
+
+		assert.Len(t, older, 1)
+
+		assert.Equal(t, models.AuditActionCreate, older[0].Action)
+
+	})
+
+	t.Run("TestGetAuditEntriesTimeRange", func(t *testing.T) {
+
+		from := time.Now().UTC().Add(time.Hour)
+
+		entries, err := auditRepository.GetAuditEntries(ctx, models.AuditLogFilter{UserId: 7, From: &from}, 10)
+
+		assert.Nil(t, err)
+
+		assert.Empty(t, entries)
+
+	})
+
+}
@@ -2,9 +2,13 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"favorite_service/internal/models"
+	"favorite_service/pkg/psql"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type FavoriteItemRepository struct {
@@ -22,7 +26,7 @@ func (r *FavoriteItemRepository) GetFavoriteItem(ctx context.Context, listId int
 
 	var favoriteItems []models.FavoriteItem
 
-	if err := r.db.WithContext(ctx).Table("favoriteitem").Debug().Where("listid = ?", listId).Find(&favoriteItems).Error; err != nil {
+	if err := psql.Conn(ctx, r.db).Table("favoriteitem").Debug().Where("listid = ?", listId).Order("position, createddate, itemid").Find(&favoriteItems).Error; err != nil {
 		return nil, err
 	}
 
@@ -30,40 +34,198 @@ func (r *FavoriteItemRepository) GetFavoriteItem(ctx context.Context, listId int
 
 }
 
-func (r *FavoriteItemRepository) CreateFavoriteItem(ctx context.Context, favoriteItem models.CreateFavoriteItem) (models.FavoriteItem, error) {
+// GetFavoriteItemsByUserId kullanıcının tüm listelerindeki ürünleri tek sorguda, liste ve eklenme sırasına göre döner.
+func (r *FavoriteItemRepository) GetFavoriteItemsByUserId(ctx context.Context, userId int) ([]models.FavoriteItem, error) {
 
-	newFavoriteItem := models.FavoriteItem{
-		ItemId: favoriteItem.ItemId,
-		ListId: favoriteItem.ListId,
+	var favoriteItems []models.FavoriteItem
+
+	if err := psql.Conn(ctx, r.db).Table("favoriteitem").
+		Select("favoriteitem.*").
+		Joins("JOIN favoritelist ON favoritelist.id = favoriteitem.listid").
+		Where("favoritelist.userid = ?", userId).
+		Order("favoriteitem.listid, favoriteitem.position, favoriteitem.createddate, favoriteitem.itemid").
+		Find(&favoriteItems).Error; err != nil {
+		return nil, err
 	}
 
-	if err := r.db.WithContext(ctx).Table("favoriteitem").Create(&newFavoriteItem).Error; err != nil {
+	return favoriteItems, nil
+
+}
+
+// GetFavoriteItemForUpdate listedeki ürünü transaction sonuna kadar FOR UPDATE ile kilitleyerek okur.
+func (r *FavoriteItemRepository) GetFavoriteItemForUpdate(ctx context.Context, listId int, itemId int) (models.FavoriteItem, error) {
+
+	var favoriteItem models.FavoriteItem
+
+	if err := psql.Conn(ctx, r.db).Table("favoriteitem").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("listid = ? AND itemid = ?", listId, itemId).
+		First(&favoriteItem).Error; err != nil {
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.FavoriteItem{}, models.ErrRecordNotFound
+		}
+
 		return models.FavoriteItem{}, err
+
 	}
 
-	return newFavoriteItem, nil
+	return favoriteItem, nil
 
 }
 
-func (r *FavoriteItemRepository) DeleteFavoriteItem(ctx context.Context, listId int, itemId int) error {
+func (r *FavoriteItemRepository) GetUserFavoritesByItemIds(ctx context.Context, userId int, itemIds []int) ([]models.FavoriteItemMembership, error) {
 
-	result := r.db.WithContext(ctx).Table("favoriteitem").Where("listid = ? AND itemid = ?", listId, itemId).Delete(&models.FavoriteItem{})
+	var memberships []models.FavoriteItemMembership
 
-	if result.Error != nil {
-		return result.Error
+	if err := psql.Conn(ctx, r.db).Table("favoriteitem").
+		Select("favoriteitem.itemid, favoriteitem.listid, favoritelist.listname").
+		Joins("JOIN favoritelist ON favoritelist.id = favoriteitem.listid").
+		Where("favoritelist.userid = ? AND favoriteitem.itemid IN ?", userId, itemIds).
+		Order("favoriteitem.itemid, favoriteitem.listid").
+		Find(&memberships).Error; err != nil {
+		return nil, err
 	}
 
-	if result.RowsAffected == 0 {
-		return models.ErrRecordNotFound
+	return memberships, nil
+
+}
+
+func (r *FavoriteItemRepository) CreateFavoriteItem(ctx context.Context, favoriteItem models.FavoriteItem) (models.FavoriteItem, error) {
+
+	err := psql.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+
+		// Yeni ürün listenin sonuna eklenir.
+		if err := tx.Table("favoriteitem").Where("listid = ?", favoriteItem.ListId).
+			Select("COALESCE(MAX(position), 0) + 1").Scan(&favoriteItem.Position).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Table("favoriteitem").Create(&favoriteItem).Error; err != nil {
+			return err
+		}
+
+		if err := bumpListVersion(tx, favoriteItem.ListId); err != nil {
+			return err
+		}
+
+		if favoriteItem.FavoritedPrice == nil || favoriteItem.FavoritedStock == nil {
+			return nil
+		}
+
+		watch := models.ProductWatch{
+			ItemId:      favoriteItem.ItemId,
+			LastPrice:   *favoriteItem.FavoritedPrice,
+			LastStock:   *favoriteItem.FavoritedStock,
+			CheckedDate: favoriteItem.CreatedDate,
+		}
+
+		return tx.Table("productwatch").Clauses(clause.OnConflict{DoNothing: true}).Create(&watch).Error
+	})
+
+	if err != nil {
+		return models.FavoriteItem{}, err
 	}
 
-	return nil
+	return favoriteItem, nil
+
+}
+
+// PatchFavoriteItem yalnızca patch içinde gönderilen alanları günceller.
+func (r *FavoriteItemRepository) PatchFavoriteItem(ctx context.Context, listId int, itemId int, patch models.PatchFavoriteItem) (models.FavoriteItem, error) {
+
+	updates := make(map[string]interface{}, 4)
+
+	if patch.Note != nil {
+		updates["note"] = *patch.Note
+	}
+
+	if patch.Quantity != nil {
+		updates["quantity"] = *patch.Quantity
+	}
+
+	if patch.Priority != nil {
+		updates["priority"] = *patch.Priority
+	}
+
+	if patch.Variant != nil {
+		updates["variant"] = *patch.Variant
+	}
+
+	var favoriteItem models.FavoriteItem
+
+	err := psql.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+
+		result := tx.Table("favoriteitem").Where("listid = ? AND itemid = ?", listId, itemId).Updates(updates)
+
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return models.ErrRecordNotFound
+		}
+
+		if err := bumpListVersion(tx, listId); err != nil {
+			return err
+		}
+
+		return tx.Table("favoriteitem").Where("listid = ? AND itemid = ?", listId, itemId).First(&favoriteItem).Error
+	})
+
+	if err != nil {
+		return models.FavoriteItem{}, err
+	}
+
+	return favoriteItem, nil
+
+}
+
+// SetFavoriteItemPositions listedeki ürünlerin sırasını itemIds sırasına göre 1'den başlayarak yazar.
+func (r *FavoriteItemRepository) SetFavoriteItemPositions(ctx context.Context, listId int, itemIds []int) error {
+
+	return psql.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+
+		for i, itemId := range itemIds {
+
+			result := tx.Table("favoriteitem").Where("listid = ? AND itemid = ?", listId, itemId).Update("position", i+1)
+
+			if result.Error != nil {
+				return result.Error
+			}
+
+			if result.RowsAffected == 0 {
+				return models.ErrRecordNotFound
+			}
+		}
+
+		return bumpListVersion(tx, listId)
+	})
+
+}
+
+func (r *FavoriteItemRepository) DeleteFavoriteItem(ctx context.Context, listId int, itemId int) error {
+
+	return psql.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+
+		result := tx.Table("favoriteitem").Where("listid = ? AND itemid = ?", listId, itemId).Delete(&models.FavoriteItem{})
+
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return models.ErrRecordNotFound
+		}
+
+		return bumpListVersion(tx, listId)
+	})
 
 }
 
 func (r *FavoriteItemRepository) DeleteFavoriteItemsByListId(ctx context.Context, listId int) error {
 
-	result := r.db.WithContext(ctx).Table("favoriteitem").Where("listid = ?", listId).Delete(&models.FavoriteItem{})
+	result := psql.Conn(ctx, r.db).Table("favoriteitem").Where("listid = ?", listId).Delete(&models.FavoriteItem{})
 
 	if result.Error != nil {
 		return result.Error
@@ -76,3 +238,12 @@ func (r *FavoriteItemRepository) DeleteFavoriteItemsByListId(ctx context.Context
 	return nil
 
 }
+
+// bumpListVersion listenin ürünleri değiştiğinde liste versiyonunu artırır; böylece listenin
+// ETag'i ürün ekleme, çıkarma ve sıralama değişikliklerini de yansıtır.
+func bumpListVersion(tx *gorm.DB, listId int) error {
+
+	return tx.Table("favoritelist").Where("id = ?", listId).
+		Updates(map[string]interface{}{"version": gorm.Expr("version + 1"), "updateddate": time.Now()}).Error
+
+}
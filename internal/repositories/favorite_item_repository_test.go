@@ -12,9 +12,14 @@ func TestItemRepository(t *testing.T) {
 
 	favoriteItemRepository := NewFavoriteItemRepository(db)
 
-	cFavoriteItem := models.CreateFavoriteItem{
-		ItemId: 6,
-		ListId: 1,
+	price := 250.0
+	stock := 0
+
+	cFavoriteItem := models.FavoriteItem{
+		ItemId:         6,
+		ListId:         1,
+		FavoritedPrice: &price,
+		FavoritedStock: &stock,
 	}
 
 	t.Run("TestCreateFavoriteItem", func(t *testing.T) {
@@ -67,6 +72,102 @@ func TestItemRepository(t *testing.T) {
 
 	})
 
+	t.Run("TestPatchFavoriteItem", func(t *testing.T) {
+
+		note := "Hediye"
+		quantity := 3
+
+		favoriteItem, err := favoriteItemRepository.PatchFavoriteItem(ctx, cFavoriteItem.ListId, cFavoriteItem.ItemId, models.PatchFavoriteItem{
+			Note:     &note,
+			Quantity: &quantity,
+		})
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, note, favoriteItem.Note)
+
+		assert.Equal(t, quantity, favoriteItem.Quantity)
+
+		_, err = favoriteItemRepository.PatchFavoriteItem(ctx, cFavoriteItem.ListId, 999, models.PatchFavoriteItem{Note: &note})
+
+		assert.Equal(t, models.ErrRecordNotFound, err)
+
+	})
+
+	t.Run("TestPatchFavoriteItemBumpsListVersion", func(t *testing.T) {
+
+		favoriteListRepository := NewFavoriteListRepository(db)
+
+		before, err := favoriteListRepository.GetListOwner(ctx, cFavoriteItem.ListId)
+
+		assert.Nil(t, err)
+
+		note := "Versiyon"
+
+		_, err = favoriteItemRepository.PatchFavoriteItem(ctx, cFavoriteItem.ListId, cFavoriteItem.ItemId, models.PatchFavoriteItem{Note: &note})
+
+		assert.Nil(t, err)
+
+		after, err := favoriteListRepository.GetListOwner(ctx, cFavoriteItem.ListId)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, before.Version+1, after.Version)
+
+	})
+
+	t.Run("TestSetFavoriteItemPositions", func(t *testing.T) {
+
+		err := favoriteItemRepository.SetFavoriteItemPositions(ctx, cFavoriteItem.ListId, []int{cFavoriteItem.ItemId})
+
+		assert.Nil(t, err)
+
+		fetchedFavoriteItemList, err := favoriteItemRepository.GetFavoriteItem(ctx, cFavoriteItem.ListId)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, cFavoriteItem.ItemId, fetchedFavoriteItemList[0].ItemId)
+
+		err = favoriteItemRepository.SetFavoriteItemPositions(ctx, cFavoriteItem.ListId, []int{999})
+
+		assert.Equal(t, models.ErrRecordNotFound, err)
+
+	})
+
+	t.Run("TestGetFavoriteItemsByUserId", func(t *testing.T) {
+
+		fetchedFavoriteItems, err := favoriteItemRepository.GetFavoriteItemsByUserId(ctx, 1)
+
+		assert.Nil(t, err)
+
+		check := false
+
+		for _, item := range fetchedFavoriteItems {
+
+			assert.Equal(t, cFavoriteItem.ListId, item.ListId)
+
+			if item.ItemId == cFavoriteItem.ItemId {
+				check = true
+			}
+
+		}
+
+		assert.True(t, check)
+
+	})
+
+	t.Run("TestGetUserFavoritesByItemIds", func(t *testing.T) {
+
+		memberships, err := favoriteItemRepository.GetUserFavoritesByItemIds(ctx, 1, []int{cFavoriteItem.ItemId, 999})
+
+		assert.Nil(t, err)
+
+		assert.Len(t, memberships, 1)
+
+		assert.Equal(t, cFavoriteItem.ListId, memberships[0].ListId)
+
+	})
+
 	t.Run("TestDeleteFavoriteItem", func(t *testing.T) {
 
 		err := favoriteItemRepository.DeleteFavoriteItem(ctx, cFavoriteItem.ListId, cFavoriteItem.ItemId)
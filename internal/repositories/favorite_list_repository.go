@@ -2,9 +2,13 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"favorite_service/internal/models"
+	"favorite_service/pkg/psql"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type FavoriteListRepository struct {
@@ -18,9 +22,25 @@ func NewFavoriteListRepository(db *gorm.DB) *FavoriteListRepository {
 }
 
 func (r *FavoriteListRepository) GetFavoriteList(ctx context.Context, userId int) ([]models.FavoriteList, error) {
+
+	return r.GetFavoriteListsByFilter(ctx, userId, models.FavoriteListFilter{})
+
+}
+
+func (r *FavoriteListRepository) GetFavoriteListsByFilter(ctx context.Context, userId int, filter models.FavoriteListFilter) ([]models.FavoriteList, error) {
 	var favoriteList []models.FavoriteList
 
-	if err := r.db.WithContext(ctx).Table("favoritelist").Debug().Where("userid = ?", userId).Find(&favoriteList).Error; err != nil {
+	query := psql.Conn(ctx, r.db).Table("favoritelist").Debug().Where("userid = ?", userId)
+
+	if filter.ListType != "" {
+		query = query.Where("listtype = ?", filter.ListType)
+	}
+
+	if filter.Visibility != "" {
+		query = query.Where("visibility = ?", filter.Visibility)
+	}
+
+	if err := query.Order("id").Find(&favoriteList).Error; err != nil {
 		return nil, err
 	}
 
@@ -30,40 +50,240 @@ func (r *FavoriteListRepository) GetFavoriteList(ctx context.Context, userId int
 
 func (r *FavoriteListRepository) CreateFavoriteList(ctx context.Context, favoriteList *models.FavoriteList) error {
 
-	if err := r.db.WithContext(ctx).Debug().Table("favoritelist").Create(&favoriteList).Error; err != nil {
+	if err := psql.Conn(ctx, r.db).Debug().Table("favoritelist").Create(&favoriteList).Error; err != nil {
 		return err
 
 	}
 	return nil
 }
 
-func (r *FavoriteListRepository) UpdateFavoriteList(ctx context.Context, listId int, updtList models.UpdateFavoriteList) (models.FavoriteList, error) {
+// UpdateFavoriteList legacy PUT için listenin adını değiştirir ve versiyonu artırır. Metadata alanları
+// yalnızca gönderildiyse yazılır; gönderilmeyen alanlar mevcut değerlerini korur. Kısmi güncelleme için PATCH kullanılır.
+// expectedVersion verilmişse güncelleme yalnızca versiyon eşleşirse yapılır, aksi halde ErrVersionMismatch döner.
+func (r *FavoriteListRepository) UpdateFavoriteList(ctx context.Context, listId int, expectedVersion *int, updtList models.UpdateFavoriteList) (models.FavoriteList, error) {
+
+	updates := map[string]interface{}{
+		"listname": updtList.ListName,
+	}
+
+	if updtList.Description != "" {
+		updates["description"] = updtList.Description
+	}
+
+	if updtList.Visibility != "" {
+		updates["visibility"] = updtList.Visibility
+	}
+
+	if updtList.ListType != "" {
+		updates["listtype"] = updtList.ListType
+	}
+
+	if updtList.Emoji != "" {
+		updates["emoji"] = updtList.Emoji
+	}
+
+	if updtList.CoverImageUrl != "" {
+		updates["coverimageurl"] = updtList.CoverImageUrl
+	}
+
+	return r.updateFavoriteList(ctx, listId, expectedVersion, updates)
+
+}
+
+// PatchFavoriteList yalnızca patch içinde gönderilen alanları günceller.
+func (r *FavoriteListRepository) PatchFavoriteList(ctx context.Context, listId int, expectedVersion *int, patch models.PatchFavoriteList) (models.FavoriteList, error) {
+
+	updates := make(map[string]interface{}, 6)
+
+	if patch.ListName != nil {
+		updates["listname"] = *patch.ListName
+	}
+
+	if patch.Description != nil {
+		updates["description"] = *patch.Description
+	}
+
+	if patch.Visibility != nil {
+		updates["visibility"] = *patch.Visibility
+	}
+
+	if patch.ListType != nil {
+		updates["listtype"] = *patch.ListType
+	}
+
+	if patch.Emoji != nil {
+		updates["emoji"] = *patch.Emoji
+	}
+
+	if patch.CoverImageUrl != nil {
+		updates["coverimageurl"] = *patch.CoverImageUrl
+	}
+
+	return r.updateFavoriteList(ctx, listId, expectedVersion, updates)
+
+}
+
+func (r *FavoriteListRepository) updateFavoriteList(ctx context.Context, listId int, expectedVersion *int, updates map[string]interface{}) (models.FavoriteList, error) {
+
+	updates["updateddate"] = time.Now()
+	updates["version"] = gorm.Expr("version + 1")
+
+	var favoriteList models.FavoriteList
+
+	err := psql.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+
+		query := tx.Table("favoritelist").Where("id = ?", listId)
+
+		if expectedVersion != nil {
+			query = query.Where("version = ?", *expectedVersion)
+		}
+
+		result := query.Updates(updates)
+
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return versionConflictOrNotFound(tx, listId, expectedVersion)
+		}
+
+		return tx.Table("favoritelist").Where("id = ?", listId).First(&favoriteList).Error
+	})
+
+	if err != nil {
+		return models.FavoriteList{}, err
+	}
+
+	return favoriteList, nil
+
+}
+
+// versionConflictOrNotFound koşullu bir yazma hiçbir satırı etkilemediğinde nedenini ayırt eder.
+func versionConflictOrNotFound(tx *gorm.DB, listId int, expectedVersion *int) error {
+
+	if expectedVersion == nil {
+		return models.ErrRecordNotFound
+	}
+
+	var count int64
+
+	if err := tx.Table("favoritelist").Where("id = ?", listId).Count(&count).Error; err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return models.ErrRecordNotFound
+	}
+
+	return models.ErrVersionMismatch
+
+}
+
+// GetDefaultListForUpdate kullanıcının varsayılan listesini transaction sonuna kadar FOR UPDATE ile kilitleyerek okur.
+// Aynı kullanıcının eşzamanlı varsayılan liste işlemleri bu kilit üzerinden sıraya girer.
+func (r *FavoriteListRepository) GetDefaultListForUpdate(ctx context.Context, userId int) (models.FavoriteList, error) {
+
+	var favoriteList models.FavoriteList
+
+	if err := psql.Conn(ctx, r.db).Table("favoritelist").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("userid = ? AND isdefault", userId).
+		First(&favoriteList).Error; err != nil {
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.FavoriteList{}, models.ErrRecordNotFound
+		}
 
-	var favoriList models.FavoriteList
-	if err := r.db.WithContext(ctx).Table("favoritelist").First(&favoriList, listId).Error; err != nil {
 		return models.FavoriteList{}, err
 	}
 
-	favoriList.ListName = updtList.ListName
+	return favoriteList, nil
+
+}
+
+// GetOrCreateDefaultList kullanıcının varsayılan listesini döner, yoksa oluşturur. created listenin bu çağrıda
+// oluşturulup oluşturulmadığını belirtir. Eşzamanlı çağrılarda idx_favoritelist_default sayesinde tek bir liste
+// oluşur; çakışan çağrı mevcut listeyi kilitleyerek tekrar okur.
+func (r *FavoriteListRepository) GetOrCreateDefaultList(ctx context.Context, userId int, listName string) (models.FavoriteList, bool, error) {
+
+	defaultList := models.FavoriteList{
+		ListName:  listName,
+		UserId:    userId,
+		IsDefault: true,
+	}
+
+	result := psql.Conn(ctx, r.db).Table("favoritelist").Clauses(clause.OnConflict{
+		Columns:     []clause.Column{{Name: "userid"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "isdefault"}}},
+		DoNothing:   true,
+	}).Create(&defaultList)
 
-	if err := r.db.WithContext(ctx).Table("favoritelist").Save(&favoriList).Error; err != nil {
+	if result.Error != nil {
+		return models.FavoriteList{}, false, result.Error
+	}
+
+	favoriteList, err := r.GetDefaultListForUpdate(ctx, userId)
+	if err != nil {
+		return models.FavoriteList{}, false, err
+	}
+
+	return favoriteList, result.RowsAffected == 1, nil
+
+}
+
+// SetDefaultList kullanıcının önceki varsayılan listesini kaldırıp listId'yi varsayılan yapar.
+func (r *FavoriteListRepository) SetDefaultList(ctx context.Context, userId int, listId int) (models.FavoriteList, error) {
+
+	var favoriteList models.FavoriteList
+
+	err := psql.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+
+		if err := tx.Table("favoritelist").Where("userid = ? AND isdefault AND id <> ?", userId, listId).
+			Updates(map[string]interface{}{"isdefault": false, "version": gorm.Expr("version + 1")}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Table("favoritelist").Where("id = ? AND userid = ?", listId, userId).
+			Updates(map[string]interface{}{"isdefault": true, "updateddate": time.Now(), "version": gorm.Expr("version + 1")})
+
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return models.ErrRecordNotFound
+		}
+
+		return tx.Table("favoritelist").Where("id = ?", listId).First(&favoriteList).Error
+	})
+
+	if err != nil {
 		return models.FavoriteList{}, err
 	}
 
-	return favoriList, nil
+	return favoriteList, nil
 
 }
 
-func (r *FavoriteListRepository) DeleteFavoriteList(ctx context.Context, listId int) error {
+func (r *FavoriteListRepository) DeleteFavoriteList(ctx context.Context, listId int, expectedVersion *int) error {
+
+	conn := psql.Conn(ctx, r.db)
+
+	query := conn.Table("favoritelist").Where("id = ?", listId)
+
+	if expectedVersion != nil {
+		query = query.Where("version = ?", *expectedVersion)
+	}
 
-	result := r.db.WithContext(ctx).Table("favoritelist").Where("id = ?", listId).Delete(&models.FavoriteList{})
+	result := query.Delete(&models.FavoriteList{})
 
 	if result.Error != nil {
 		return result.Error
 	}
 
 	if result.RowsAffected == 0 {
-		return models.ErrRecordNotFound
+		return versionConflictOrNotFound(conn, listId, expectedVersion)
 	}
 
 	return nil
@@ -74,7 +294,33 @@ func (r *FavoriteListRepository) GetListOwner(ctx context.Context, listId int) (
 
 	var favoriteList models.FavoriteList
 
-	if err := r.db.WithContext(ctx).Table("favoritelist").Where("id = ?", listId).First(&favoriteList).Error; err != nil {
+	if err := psql.Conn(ctx, r.db).Table("favoritelist").Where("id = ?", listId).First(&favoriteList).Error; err != nil {
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.FavoriteList{}, models.ErrRecordNotFound
+		}
+
+		return models.FavoriteList{}, err
+
+	}
+
+	return favoriteList, nil
+
+}
+
+// GetFavoriteListForUpdate listeyi transaction sonuna kadar FOR UPDATE ile kilitleyerek okur.
+func (r *FavoriteListRepository) GetFavoriteListForUpdate(ctx context.Context, listId int) (models.FavoriteList, error) {
+
+	var favoriteList models.FavoriteList
+
+	if err := psql.Conn(ctx, r.db).Table("favoritelist").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", listId).
+		First(&favoriteList).Error; err != nil {
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.FavoriteList{}, models.ErrRecordNotFound
+		}
 
 		return models.FavoriteList{}, err
 
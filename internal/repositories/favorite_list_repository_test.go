@@ -72,13 +72,93 @@ func TestListRepository(t *testing.T) {
 
 	})
 
+	t.Run("TestGetFavoriteListsByFilter", func(t *testing.T) {
+
+		fetchedFavoriteLists, err := favoriteListRepository.GetFavoriteListsByFilter(ctx, cFavoriteList.UserId, models.FavoriteListFilter{ListType: models.ListTypeWishlist})
+
+		assert.Nil(t, err)
+
+		for _, list := range fetchedFavoriteLists {
+			assert.Equal(t, models.ListTypeWishlist, list.ListType)
+		}
+
+		fetchedFavoriteLists, err = favoriteListRepository.GetFavoriteListsByFilter(ctx, cFavoriteList.UserId, models.FavoriteListFilter{ListType: models.ListTypeCollection})
+
+		assert.Nil(t, err)
+
+		assert.Empty(t, fetchedFavoriteLists)
+
+	})
+
+	t.Run("TestPatchFavoriteList", func(t *testing.T) {
+
+		listType := models.ListTypeCollection
+
+		patchedList, err := favoriteListRepository.PatchFavoriteList(ctx, cFavoriteList.Id, nil, models.PatchFavoriteList{ListType: &listType})
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, listType, patchedList.ListType)
+
+		assert.Equal(t, cFavoriteList.ListName, patchedList.ListName)
+
+		_, err = favoriteListRepository.PatchFavoriteList(ctx, 888, nil, models.PatchFavoriteList{ListType: &listType})
+
+		assert.Equal(t, models.ErrRecordNotFound, err)
+
+	})
+
+	t.Run("TestGetOrCreateDefaultList", func(t *testing.T) {
+
+		_, err := favoriteListRepository.GetDefaultListForUpdate(ctx, 3)
+
+		assert.Equal(t, models.ErrRecordNotFound, err)
+
+		defaultList, created, err := favoriteListRepository.GetOrCreateDefaultList(ctx, 3, models.DefaultListName)
+
+		assert.Nil(t, err)
+
+		assert.True(t, created)
+
+		assert.True(t, defaultList.IsDefault)
+
+		again, created, err := favoriteListRepository.GetOrCreateDefaultList(ctx, 3, models.DefaultListName)
+
+		assert.Nil(t, err)
+
+		assert.False(t, created)
+
+		assert.Equal(t, defaultList.Id, again.Id)
+
+		locked, err := favoriteListRepository.GetDefaultListForUpdate(ctx, 3)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, defaultList.Id, locked.Id)
+
+	})
+
+	t.Run("TestSetDefaultList", func(t *testing.T) {
+
+		defaultList, err := favoriteListRepository.SetDefaultList(ctx, cFavoriteList.UserId, cFavoriteList.Id)
+
+		assert.Nil(t, err)
+
+		assert.True(t, defaultList.IsDefault)
+
+		_, err = favoriteListRepository.SetDefaultList(ctx, 2, cFavoriteList.Id)
+
+		assert.Equal(t, models.ErrRecordNotFound, err)
+
+	})
+
 	t.Run("TestUpdateFavoriteList", func(t *testing.T) {
 
 		updatedList := models.UpdateFavoriteList{
 			ListName: "UpdateDeneme",
 		}
 
-		updatedFavoriteList, err := favoriteListRepository.UpdateFavoriteList(ctx, cFavoriteList.Id, updatedList)
+		updatedFavoriteList, err := favoriteListRepository.UpdateFavoriteList(ctx, cFavoriteList.Id, nil, updatedList)
 
 		assert.Nil(t, err)
 
@@ -86,10 +166,63 @@ func TestListRepository(t *testing.T) {
 
 		assert.Equal(t, updatedList.ListName, updatedFavoriteList.ListName)
 
+		cFavoriteList.Version = updatedFavoriteList.Version
+
+	})
+
+	t.Run("TestUpdateFavoriteListKeepsMetadata", func(t *testing.T) {
+
+		description := "Doğum günü hediyeleri"
+		visibility := models.VisibilityShared
+
+		patched, err := favoriteListRepository.PatchFavoriteList(ctx, cFavoriteList.Id, nil, models.PatchFavoriteList{Description: &description, Visibility: &visibility})
+
+		assert.Nil(t, err)
+
+		updatedFavoriteList, err := favoriteListRepository.UpdateFavoriteList(ctx, cFavoriteList.Id, nil, models.UpdateFavoriteList{ListName: "SadeceIsim"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "SadeceIsim", updatedFavoriteList.ListName)
+		assert.Equal(t, description, updatedFavoriteList.Description)
+		assert.Equal(t, visibility, updatedFavoriteList.Visibility)
+		assert.Equal(t, patched.Version+1, updatedFavoriteList.Version)
+
+		cFavoriteList.Version = updatedFavoriteList.Version
+
+	})
+
+	t.Run("TestUpdateFavoriteListVersionMismatch", func(t *testing.T) {
+
+		staleVersion := cFavoriteList.Version - 1
+
+		_, err := favoriteListRepository.UpdateFavoriteList(ctx, cFavoriteList.Id, &staleVersion, models.UpdateFavoriteList{ListName: "Eski"})
+
+		assert.Equal(t, models.ErrVersionMismatch, err)
+
+		currentVersion := cFavoriteList.Version
+
+		updatedFavoriteList, err := favoriteListRepository.UpdateFavoriteList(ctx, cFavoriteList.Id, &currentVersion, models.UpdateFavoriteList{ListName: "Yeni"})
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, currentVersion+1, updatedFavoriteList.Version)
+
+		cFavoriteList.Version = updatedFavoriteList.Version
+
+		_, err = favoriteListRepository.UpdateFavoriteList(ctx, 888, &currentVersion, models.UpdateFavoriteList{ListName: "Yok"})
+
+		assert.Equal(t, models.ErrRecordNotFound, err)
+
 	})
 
 	t.Run("TestDeleteFavoriteList", func(t *testing.T) {
-		err := favoriteListRepository.DeleteFavoriteList(ctx, cFavoriteList.Id)
+		staleVersion := cFavoriteList.Version - 1
+
+		err := favoriteListRepository.DeleteFavoriteList(ctx, cFavoriteList.Id, &staleVersion)
+
+		assert.Equal(t, models.ErrVersionMismatch, err)
+
+		err = favoriteListRepository.DeleteFavoriteList(ctx, cFavoriteList.Id, &cFavoriteList.Version)
 
 		assert.Nil(t, err)
 
@@ -107,13 +240,13 @@ func TestListRepository(t *testing.T) {
 	})
 
 	t.Run("TestDeleteFavoriteListAgain", func(t *testing.T) {
-		err := favoriteListRepository.DeleteFavoriteList(ctx, cFavoriteList.Id)
+		err := favoriteListRepository.DeleteFavoriteList(ctx, cFavoriteList.Id, nil)
 
 		fmt.Println(models.ErrRecordNotFound, err)
 
 		assert.Equal(t, models.ErrRecordNotFound, err)
 
-		err = favoriteListRepository.DeleteFavoriteList(ctx, 888)
+		err = favoriteListRepository.DeleteFavoriteList(ctx, 888, nil)
 
 		fmt.Println(models.ErrRecordNotFound, err)
 
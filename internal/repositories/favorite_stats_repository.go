@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	"favorite_service/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type FavoriteStatsRepository struct {
+	db *gorm.DB
+}
+
+func NewFavoriteStatsRepository(db *gorm.DB) *FavoriteStatsRepository {
+	return &FavoriteStatsRepository{
+		db: db,
+	}
+}
+
+func (r *FavoriteStatsRepository) CountFavoriteLists(ctx context.Context) (int64, error) {
+
+	var count int64
+
+	if err := r.db.WithContext(ctx).Table("favoritelist").Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+
+}
+
+func (r *FavoriteStatsRepository) CountFavoriteItems(ctx context.Context) (int64, error) {
+
+	var count int64
+
+	if err := r.db.WithContext(ctx).Table("favoriteitem").Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+
+}
+
+func (r *FavoriteStatsRepository) GetTopFavoritedProducts(ctx context.Context, limit int) ([]models.ProductFavoriteCount, error) {
+
+	var counts []models.ProductFavoriteCount
+
+	if err := r.db.WithContext(ctx).Table("favoriteitem").
+		Select("itemid, COUNT(*) AS favoritecount").
+		Group("itemid").
+		Order("favoritecount DESC, itemid").
+		Limit(limit).
+		Find(&counts).Error; err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+
+}
+
+func (r *FavoriteStatsRepository) GetPopularProducts(ctx context.Context, since time.Time, limit int) ([]models.ProductFavoriteCount, error) {
+
+	var counts []models.ProductFavoriteCount
+
+	if err := r.db.WithContext(ctx).Table("favoriteitemdailycount").
+		Select("itemid, SUM(favoritecount) AS favoritecount").
+		Where("day >= ?", since.Format(time.DateOnly)).
+		Group("itemid").
+		Order("favoritecount DESC, itemid").
+		Limit(limit).
+		Find(&counts).Error; err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+
+}
+
+func (r *FavoriteStatsRepository) RefreshPopularitySummary(ctx context.Context) error {
+
+	return r.db.WithContext(ctx).Exec("REFRESH MATERIALIZED VIEW CONCURRENTLY favoriteitemdailycount").Error
+
+}
+
+func (r *FavoriteStatsRepository) GetDistinctUserFavoriteCounts(ctx context.Context, itemIds []int) ([]models.ProductFavoriteCount, error) {
+
+	var counts []models.ProductFavoriteCount
+
+	if err := r.db.WithContext(ctx).Table("favoriteitem").
+		Select("favoriteitem.itemid, COUNT(DISTINCT favoritelist.userid) AS favoritecount").
+		Joins("JOIN favoritelist ON favoritelist.id = favoriteitem.listid").
+		Where("favoriteitem.itemid IN ?", itemIds).
+		Group("favoriteitem.itemid").
+		Find(&counts).Error; err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+
+}
@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatsRepository(t *testing.T) {
+
+	favoriteStatsRepository := NewFavoriteStatsRepository(db)
+
+	t.Run("TestCountFavoriteLists", func(t *testing.T) {
+
+		count, err := favoriteStatsRepository.CountFavoriteLists(ctx)
+
+		assert.Nil(t, err)
+
+		assert.GreaterOrEqual(t, count, int64(1))
+
+	})
+
+	t.Run("TestCountFavoriteItems", func(t *testing.T) {
+
+		count, err := favoriteStatsRepository.CountFavoriteItems(ctx)
+
+		assert.Nil(t, err)
+
+		assert.GreaterOrEqual(t, count, int64(1))
+
+	})
+
+	t.Run("TestGetTopFavoritedProducts", func(t *testing.T) {
+
+		counts, err := favoriteStatsRepository.GetTopFavoritedProducts(ctx, 2)
+
+		assert.Nil(t, err)
+
+		assert.LessOrEqual(t, len(counts), 2)
+
+		for i := 1; i < len(counts); i++ {
+			assert.GreaterOrEqual(t, counts[i-1].FavoriteCount, counts[i].FavoriteCount)
+		}
+
+	})
+
+}
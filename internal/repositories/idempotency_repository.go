@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"context"
+	"favorite_service/internal/models"
+	"favorite_service/pkg/psql"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdempotencyRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyRepository(db *gorm.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{
+		db: db,
+	}
+}
+
+// ReserveIdempotencyKey anahtarı in_progress olarak kaydetmeye çalışır. Anahtar yoksa, süresi
+// dolmuşsa ya da staleBefore'dan önce başlayıp tamamlanmamışsa kayıt bu istek adına alınır
+// ve true döner. Aksi halde mevcut kayıt false ile döner. Tek bir INSERT ... ON CONFLICT
+// kullanıldığından eşzamanlı iki istekten yalnızca biri anahtarı alabilir.
+func (r *IdempotencyRepository) ReserveIdempotencyKey(ctx context.Context, record models.IdempotencyRecord, staleBefore time.Time) (models.IdempotencyRecord, bool, error) {
+
+	conn := psql.Conn(ctx, r.db)
+
+	record.Status = models.IdempotencyStatusInProgress
+
+	result := conn.Table("idempotencykey").
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "scope"}, {Name: "idempotencykey"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"fingerprint":    gorm.Expr("excluded.fingerprint"),
+				"status":         models.IdempotencyStatusInProgress,
+				"responsestatus": nil,
+				"contenttype":    nil,
+				"responsebody":   nil,
+				"createddate":    gorm.Expr("excluded.createddate"),
+				"expiresat":      gorm.Expr("excluded.expiresat"),
+			}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				clause.Or(
+					gorm.Expr("idempotencykey.expiresat < excluded.createddate"),
+					gorm.Expr("idempotencykey.status = ? AND idempotencykey.createddate < ?", models.IdempotencyStatusInProgress, staleBefore),
+				),
+			}},
+		}).
+		Create(&record)
+
+	if result.Error != nil {
+		return models.IdempotencyRecord{}, false, result.Error
+	}
+
+	if result.RowsAffected == 1 {
+		return record, true, nil
+	}
+
+	var existing models.IdempotencyRecord
+
+	if err := conn.Table("idempotencykey").
+		Where("scope = ? AND idempotencykey = ?", record.Scope, record.IdempotencyKey).
+		First(&existing).Error; err != nil {
+
+		if err == gorm.ErrRecordNotFound {
+			return models.IdempotencyRecord{}, false, models.ErrRecordNotFound
+		}
+
+		return models.IdempotencyRecord{}, false, err
+	}
+
+	return existing, false, nil
+
+}
+
+func (r *IdempotencyRepository) CompleteIdempotencyKey(ctx context.Context, scope string, key string, status int, contentType string, body []byte) error {
+
+	result := psql.Conn(ctx, r.db).Table("idempotencykey").
+		Where("scope = ? AND idempotencykey = ? AND status = ?", scope, key, models.IdempotencyStatusInProgress).
+		Updates(map[string]interface{}{
+			"status":         models.IdempotencyStatusCompleted,
+			"responsestatus": status,
+			"contenttype":    contentType,
+			"responsebody":   body,
+		})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return models.ErrRecordNotFound
+	}
+
+	return nil
+
+}
+
+// ReleaseIdempotencyKey tamamlanmamış kaydı siler; istemci aynı anahtarla tekrar deneyebilir.
+func (r *IdempotencyRepository) ReleaseIdempotencyKey(ctx context.Context, scope string, key string) error {
+
+	return psql.Conn(ctx, r.db).Table("idempotencykey").
+		Where("scope = ? AND idempotencykey = ? AND status = ?", scope, key, models.IdempotencyStatusInProgress).
+		Delete(&models.IdempotencyRecord{}).Error
+
+}
+
+func (r *IdempotencyRepository) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error) {
+
+	result := psql.Conn(ctx, r.db).Table("idempotencykey").
+		Where("expiresat < ?", now).
+		Delete(&models.IdempotencyRecord{})
+
+	return result.RowsAffected, result.Error
+
+}
@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"favorite_service/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyRepository(t *testing.T) {
+
+	idempotencyRepository := NewIdempotencyRepository(db)
+
+	now := time.Now().UTC()
+
+	record := models.IdempotencyRecord{
+		Scope:          "scope-1",
+		IdempotencyKey: "key-1",
+		Fingerprint:    "fingerprint-1",
+		CreatedDate:    now,
+		ExpiresAt:      now.Add(time.Hour),
+	}
+
+	t.Run("TestReserveIdempotencyKey", func(t *testing.T) {
+
+		_, reserved, err := idempotencyRepository.ReserveIdempotencyKey(ctx, record, now.Add(-time.Minute))
+
+		assert.Nil(t, err)
+
+		assert.True(t, reserved)
+
+		existing, reserved, err := idempotencyRepository.ReserveIdempotencyKey(ctx, record, now.Add(-time.Minute))
+
+		assert.Nil(t, err)
+
+		assert.False(t, reserved)
+
+		assert.Equal(t, models.IdempotencyStatusInProgress, existing.Status)
+
+	})
+
+	t.Run("TestReserveIdempotencyKeyStaleInProgress", func(t *testing.T) {
+
+		_, reserved, err := idempotencyRepository.ReserveIdempotencyKey(ctx, record, now.Add(time.Minute))
+
+		assert.Nil(t, err)
+
+		assert.True(t, reserved)
+
+	})
+
+	t.Run("TestCompleteIdempotencyKey", func(t *testing.T) {
+
+		err := idempotencyRepository.CompleteIdempotencyKey(ctx, record.Scope, record.IdempotencyKey, 200, "application/json", []byte(`{"ok":true}`))
+
+		assert.Nil(t, err)
+
+		existing, reserved, err := idempotencyRepository.ReserveIdempotencyKey(ctx, record, now.Add(time.Minute))
+
+		assert.Nil(t, err)
+
+		assert.False(t, reserved)
+
+		assert.Equal(t, models.IdempotencyStatusCompleted, existing.Status)
+
+		assert.Equal(t, []byte(`{"ok":true}`), existing.ResponseBody)
+
+		err = idempotencyRepository.CompleteIdempotencyKey(ctx, record.Scope, record.IdempotencyKey, 200, "application/json", nil)
+
+		assert.Equal(t, models.ErrRecordNotFound, err)
+
+	})
+
+	t.Run("TestReleaseIdempotencyKey", func(t *testing.T) {
+
+		released := record
+		released.IdempotencyKey = "key-2"
+
+		_, reserved, err := idempotencyRepository.ReserveIdempotencyKey(ctx, released, now.Add(-time.Minute))
+
+		assert.Nil(t, err)
+
+		assert.True(t, reserved)
+
+		assert.Nil(t, idempotencyRepository.ReleaseIdempotencyKey(ctx, released.Scope, released.IdempotencyKey))
+
+		_, reserved, err = idempotencyRepository.ReserveIdempotencyKey(ctx, released, now.Add(-time.Minute))
+
+		assert.Nil(t, err)
+
+		assert.True(t, reserved)
+
+	})
+
+	t.Run("TestDeleteExpiredIdempotencyKeys", func(t *testing.T) {
+
+		deleted, err := idempotencyRepository.DeleteExpiredIdempotencyKeys(ctx, now.Add(2*time.Hour))
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, int64(2), deleted)
+
+	})
+
+}
@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"favorite_service/internal/events"
+	"favorite_service/internal/models"
+	"favorite_service/pkg/psql"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type OutboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) *OutboxRepository {
+	return &OutboxRepository{
+		db: db,
+	}
+}
+
+// AddEvent context'teki transaction'a katılır; böylece event, onu üreten
+// değişiklikle birlikte commit edilir ya da geri alınır.
+func (r *OutboxRepository) AddEvent(ctx context.Context, event events.Event) error {
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	outboxEvent := models.OutboxEvent{
+		Id:          event.ID,
+		EventType:   event.Type,
+		Version:     event.Version,
+		Payload:     string(payload),
+		CreatedDate: event.OccurredAt,
+	}
+
+	return psql.Conn(ctx, r.db).Table("outboxevent").Create(&outboxEvent).Error
+
+}
+
+// ClaimPendingEvents zamanı gelen yayınlanmamış eventleri tek bir UPDATE ile sahiplenir:
+// nextattemptdate leaseUntil'e ötelenir, böylece satır kilidi yalnızca bu sorgu süresince tutulur
+// ve diğer relay'ler (SKIP LOCKED) aynı eventleri almaz. Eventler oluşturulma sırasıyla döner.
+func (r *OutboxRepository) ClaimPendingEvents(ctx context.Context, limit int, leaseUntil time.Time) ([]events.PendingEvent, error) {
+
+	var outboxEvents []models.OutboxEvent
+
+	if err := psql.Conn(ctx, r.db).Raw(`UPDATE outboxevent SET nextattemptdate = ?
+		WHERE id IN (
+			SELECT id FROM outboxevent
+			WHERE publisheddate IS NULL AND deadletterdate IS NULL AND nextattemptdate <= ?
+			ORDER BY createddate, id
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED)
+		RETURNING id, payload, attempts, createddate`,
+		leaseUntil, time.Now(), limit).Scan(&outboxEvents).Error; err != nil {
+		return nil, err
+	}
+
+	sort.Slice(outboxEvents, func(i, j int) bool {
+		if !outboxEvents[i].CreatedDate.Equal(outboxEvents[j].CreatedDate) {
+			return outboxEvents[i].CreatedDate.Before(outboxEvents[j].CreatedDate)
+		}
+		return outboxEvents[i].Id < outboxEvents[j].Id
+	})
+
+	pending := make([]events.PendingEvent, 0, len(outboxEvents))
+	for _, outboxEvent := range outboxEvents {
+		var event events.Event
+		if err := json.Unmarshal([]byte(outboxEvent.Payload), &event); err != nil {
+			return nil, err
+		}
+		pending = append(pending, events.PendingEvent{Event: event, Attempts: outboxEvent.Attempts})
+	}
+
+	return pending, nil
+
+}
+
+func (r *OutboxRepository) MarkPublished(ctx context.Context, eventId string) error {
+
+	return psql.Conn(ctx, r.db).Table("outboxevent").Where("id = ?", eventId).
+		Updates(map[string]interface{}{
+			"publisheddate": time.Now(),
+			"attempts":      gorm.Expr("attempts + 1"),
+			"lasterror":     nil,
+		}).Error
+
+}
+
+// MarkFailed bir sonraki deneme zamanını yazar. deadLetter true ise event bir daha denenmez.
+func (r *OutboxRepository) MarkFailed(ctx context.Context, eventId string, publishErr error, nextAttemptDate time.Time, deadLetter bool) error {
+
+	updates := map[string]interface{}{
+		"attempts":        gorm.Expr("attempts + 1"),
+		"lasterror":       publishErr.Error(),
+		"nextattemptdate": nextAttemptDate,
+	}
+
+	if deadLetter {
+		updates["deadletterdate"] = time.Now()
+	}
+
+	return psql.Conn(ctx, r.db).Table("outboxevent").Where("id = ?", eventId).Updates(updates).Error
+
+}
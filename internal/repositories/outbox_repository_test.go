@@ -0,0 +1,132 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"favorite_service/internal/events"
+	"favorite_service/pkg/psql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutboxRepository(t *testing.T) {
+
+	outboxRepository := NewOutboxRepository(db)
+	transactor := psql.NewTransactor(db)
+
+	event, err := events.NewEvent(events.TypeFavoriteItemAdded, events.FavoriteItemEventData{UserId: 1, ListId: 1, ItemId: 3})
+
+	assert.Nil(t, err)
+
+	t.Run("TestAddEventRollback", func(t *testing.T) {
+
+		rolledBack, err := events.NewEvent(events.TypeFavoriteItemAdded, events.FavoriteItemEventData{UserId: 1, ListId: 1, ItemId: 4})
+
+		assert.Nil(t, err)
+
+		err = transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := outboxRepository.AddEvent(ctx, rolledBack); err != nil {
+				return err
+			}
+			return errors.New("rollback")
+		})
+
+		assert.NotNil(t, err)
+
+		pending, err := outboxRepository.ClaimPendingEvents(ctx, 100, time.Now())
+
+		assert.Nil(t, err)
+
+		for _, pendingEvent := range pending {
+			assert.NotEqual(t, rolledBack.ID, pendingEvent.ID)
+		}
+
+	})
+
+	t.Run("TestAddEvent", func(t *testing.T) {
+
+		err := transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			return outboxRepository.AddEvent(ctx, event)
+		})
+
+		assert.Nil(t, err)
+
+		pending, err := outboxRepository.ClaimPendingEvents(ctx, 100, time.Now())
+
+		assert.Nil(t, err)
+
+		check := false
+		for _, pendingEvent := range pending {
+			if pendingEvent.ID == event.ID {
+				check = true
+				assert.Equal(t, event.Type, pendingEvent.Type)
+			}
+		}
+
+		assert.True(t, check)
+
+	})
+
+	t.Run("TestMarkFailedDeadLetter", func(t *testing.T) {
+
+		failing, err := events.NewEvent(events.TypeFavoriteItemAdded, events.FavoriteItemEventData{UserId: 1, ListId: 1, ItemId: 5})
+
+		assert.Nil(t, err)
+
+		assert.Nil(t, outboxRepository.AddEvent(ctx, failing))
+
+		assert.Nil(t, outboxRepository.MarkFailed(ctx, failing.ID, errors.New("publisher hatası"), time.Now(), true))
+
+		pending, err := outboxRepository.ClaimPendingEvents(ctx, 100, time.Now())
+
+		assert.Nil(t, err)
+
+		for _, pendingEvent := range pending {
+			assert.NotEqual(t, failing.ID, pendingEvent.ID)
+		}
+
+	})
+
+	t.Run("TestClaimPendingEventsLease", func(t *testing.T) {
+
+		leased, err := events.NewEvent(events.TypeFavoriteItemAdded, events.FavoriteItemEventData{UserId: 1, ListId: 1, ItemId: 6})
+
+		assert.Nil(t, err)
+
+		assert.Nil(t, outboxRepository.AddEvent(ctx, leased))
+
+		claimed, err := outboxRepository.ClaimPendingEvents(ctx, 100, time.Now().Add(time.Hour))
+
+		assert.Nil(t, err)
+
+		assert.NotEmpty(t, claimed)
+
+		again, err := outboxRepository.ClaimPendingEvents(ctx, 100, time.Now())
+
+		assert.Nil(t, err)
+
+		for _, pendingEvent := range again {
+			assert.NotEqual(t, leased.ID, pendingEvent.ID)
+		}
+
+	})
+
+	t.Run("TestMarkPublished", func(t *testing.T) {
+
+		err := outboxRepository.MarkPublished(ctx, event.ID)
+
+		assert.Nil(t, err)
+
+		pending, err := outboxRepository.ClaimPendingEvents(ctx, 100, time.Now())
+
+		assert.Nil(t, err)
+
+		for _, pendingEvent := range pending {
+			assert.NotEqual(t, event.ID, pendingEvent.ID)
+		}
+
+	})
+
+}
@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+	"favorite_service/internal/models"
+	"favorite_service/pkg/psql"
+	"strconv"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PrivacyRepository struct {
+	db *gorm.DB
+}
+
+func NewPrivacyRepository(db *gorm.DB) *PrivacyRepository {
+	return &PrivacyRepository{
+		db: db,
+	}
+}
+
+// EraseUserData kullanıcının listelerini, ürünlerini ve alarmlarını siler; audit kayıtlarındaki
+// kullanıcı id'sini, istek id'sini ve liste/ürün görüntülerini temizler. Kullanıcıya ait outbox
+// eventleri, webhook teslimatları ve saklanan idempotency yanıtları da silinir. Tüm adımlar tek bir
+// transaction içinde çalışır, herhangi biri başarısız olursa hiçbir şey silinmez.
+func (r *PrivacyRepository) EraseUserData(ctx context.Context, userId int) (models.ErasureResult, error) {
+
+	result := models.ErasureResult{UserId: userId}
+
+	err := psql.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+
+		userLists := tx.Table("favoritelist").Select("id").Where("userid = ?", userId)
+
+		// Audit kayıtları silinmeden önce listeler üzerinden bulunur; başka bir kullanıcının
+		// yaptığı değişiklikler de bu kullanıcının listelerine ait görüntüler içerebilir.
+		audit := tx.Table("auditlog").
+			Where("actoruserid = ? OR listid IN (?)", userId, userLists).
+			Updates(map[string]interface{}{"actoruserid": 0, "requestid": "", "before": nil, "after": nil})
+
+		if audit.Error != nil {
+			return audit.Error
+		}
+
+		// Event zarfındaki data.user_id üzerinden bulunur; liste adı gibi alanlar da aynı payload içindedir.
+		eventOwner := strconv.Itoa(userId)
+
+		outbox := tx.Table("outboxevent").Where("payload -> 'data' ->> 'user_id' = ?", eventOwner).Delete(&models.OutboxEvent{})
+
+		if outbox.Error != nil {
+			return outbox.Error
+		}
+
+		deliveries := tx.Table("webhookdelivery").Where("payload -> 'data' ->> 'user_id' = ?", eventOwner).Delete(&models.WebhookDelivery{})
+
+		if deliveries.Error != nil {
+			return deliveries.Error
+		}
+
+		// Idempotency anahtarları doğrulanmış kullanıcı id'si ile ayrılır.
+		idempotencyKeys := tx.Table("idempotencykey").Where("scope = ?", eventOwner).Delete(&models.IdempotencyRecord{})
+
+		if idempotencyKeys.Error != nil {
+			return idempotencyKeys.Error
+		}
+
+		items := tx.Table("favoriteitem").Clauses(clause.Returning{}).Where("listid IN (?)", userLists).Delete(&result.Items)
+
+		if items.Error != nil {
+			return items.Error
+		}
+
+		alerts := tx.Table("productalert").Where("userid = ?", userId).Delete(&models.ProductAlert{})
+
+		if alerts.Error != nil {
+			return alerts.Error
+		}
+
+		lists := tx.Table("favoritelist").Clauses(clause.Returning{}).Where("userid = ?", userId).Delete(&result.Lists)
+
+		if lists.Error != nil {
+			return lists.Error
+		}
+
+		result.AuditEntriesAnonymized = audit.RowsAffected
+		result.EventsPurged = outbox.RowsAffected
+		result.WebhookDeliveriesPurged = deliveries.RowsAffected
+		result.IdempotencyKeysPurged = idempotencyKeys.RowsAffected
+		result.ItemsDeleted = items.RowsAffected
+		result.AlertsDeleted = alerts.RowsAffected
+		result.ListsDeleted = lists.RowsAffected
+
+		return nil
+	})
+
+	if err != nil {
+		return models.ErasureResult{}, err
+	}
+
+	return result, nil
+
+}
@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"encoding/json"
+	"favorite_service/internal/events"
+	"favorite_service/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrivacyRepository(t *testing.T) {
+
+	privacyRepository := NewPrivacyRepository(db)
+	favoriteListRepository := NewFavoriteListRepository(db)
+	favoriteItemRepository := NewFavoriteItemRepository(db)
+	auditRepository := NewAuditRepository(db)
+	outboxRepository := NewOutboxRepository(db)
+	idempotencyRepository := NewIdempotencyRepository(db)
+
+	userId := 900
+
+	t.Run("TestEraseUserData", func(t *testing.T) {
+
+		list := models.FavoriteList{ListName: "Silinecek Liste", UserId: userId}
+
+		assert.Nil(t, favoriteListRepository.CreateFavoriteList(ctx, &list))
+
+		_, err := favoriteItemRepository.CreateFavoriteItem(ctx, models.FavoriteItem{ItemId: 1, ListId: list.Id})
+
+		assert.Nil(t, err)
+
+		assert.Nil(t, db.Table("productalert").Create(&models.ProductAlert{UserId: userId, ListId: list.Id, ItemId: 1, AlertType: models.AlertTypeBackInStock}).Error)
+
+		entry := models.AuditEntry{
+			ActorUserId: userId,
+			RequestId:   "req-erase",
+			EntityType:  models.AuditEntityList,
+			Action:      models.AuditActionCreate,
+			ListId:      list.Id,
+			After:       json.RawMessage(`{"list_name":"Silinecek Liste"}`),
+		}
+
+		assert.Nil(t, auditRepository.AddAuditEntry(ctx, &entry))
+
+		event, err := events.NewEvent(events.TypeFavoriteListCreated, events.FavoriteListEventData{UserId: userId, ListId: list.Id, ListName: list.ListName})
+
+		assert.Nil(t, err)
+
+		assert.Nil(t, outboxRepository.AddEvent(ctx, event))
+
+		now := time.Now()
+
+		_, _, err = idempotencyRepository.ReserveIdempotencyKey(ctx, models.IdempotencyRecord{
+			Scope:          "900",
+			IdempotencyKey: "erase-key",
+			Fingerprint:    "fingerprint",
+			CreatedDate:    now,
+			ExpiresAt:      now.Add(time.Hour),
+		}, now.Add(-time.Minute))
+
+		assert.Nil(t, err)
+
+		result, err := privacyRepository.EraseUserData(ctx, userId)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, int64(1), result.ListsDeleted)
+
+		assert.Equal(t, int64(1), result.ItemsDeleted)
+
+		assert.Equal(t, int64(1), result.AlertsDeleted)
+
+		assert.Equal(t, int64(1), result.AuditEntriesAnonymized)
+
+		assert.Equal(t, int64(1), result.EventsPurged)
+
+		assert.Equal(t, int64(1), result.IdempotencyKeysPurged)
+
+		assert.Len(t, result.Lists, 1)
+
+		assert.Equal(t, list.Id, result.Lists[0].Id)
+
+		assert.Len(t, result.Items, 1)
+
+		assert.Equal(t, 1, result.Items[0].ItemId)
+
+		var remaining int64
+
+		assert.Nil(t, db.Table("outboxevent").Where("id = ?", event.ID).Count(&remaining).Error)
+
+		assert.Zero(t, remaining)
+
+		lists, err := favoriteListRepository.GetFavoriteList(ctx, userId)
+
+		assert.Nil(t, err)
+
+		assert.Empty(t, lists)
+
+		entries, err := auditRepository.GetAuditEntries(ctx, models.AuditLogFilter{ListId: list.Id}, 10)
+
+		assert.Nil(t, err)
+
+		assert.Len(t, entries, 1)
+
+		assert.Zero(t, entries[0].ActorUserId)
+
+		assert.Empty(t, entries[0].RequestId)
+
+		assert.Nil(t, entries[0].After)
+
+	})
+
+	t.Run("TestEraseUserDataNoData", func(t *testing.T) {
+
+		result, err := privacyRepository.EraseUserData(ctx, userId)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, userId, result.UserId)
+
+		assert.Zero(t, result.ListsDeleted)
+
+		assert.Zero(t, result.ItemsDeleted)
+
+		assert.Zero(t, result.EventsPurged)
+
+		assert.Empty(t, result.Lists)
+
+	})
+
+}
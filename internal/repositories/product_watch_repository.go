@@ -0,0 +1,122 @@
+package repositories
+
+import (
+	"context"
+	"favorite_service/internal/models"
+	"favorite_service/pkg/psql"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ProductWatchRepository struct {
+	db *gorm.DB
+}
+
+func NewProductWatchRepository(db *gorm.DB) *ProductWatchRepository {
+	return &ProductWatchRepository{
+		db: db,
+	}
+}
+
+func (r *ProductWatchRepository) GetFavoritedItemIds(ctx context.Context, afterItemId int, limit int) ([]int, error) {
+
+	var itemIds []int
+
+	if err := psql.Conn(ctx, r.db).Table("favoriteitem").
+		Distinct("itemid").
+		Where("itemid > ?", afterItemId).
+		Order("itemid").
+		Limit(limit).
+		Pluck("itemid", &itemIds).Error; err != nil {
+		return nil, err
+	}
+
+	return itemIds, nil
+
+}
+
+func (r *ProductWatchRepository) GetProductWatches(ctx context.Context, itemIds []int) ([]models.ProductWatch, error) {
+
+	var watches []models.ProductWatch
+
+	if err := psql.Conn(ctx, r.db).Table("productwatch").Where("itemid IN ?", itemIds).Find(&watches).Error; err != nil {
+		return nil, err
+	}
+
+	return watches, nil
+
+}
+
+// SaveProductWatch ürünün son durumunu kaydeder ve önceki duruma göre oluşan
+// fiyat düşüşü / stoğa girme alarmlarını aynı transaction içinde favorileyen kullanıcılara yazar.
+func (r *ProductWatchRepository) SaveProductWatch(ctx context.Context, previous *models.ProductWatch, product models.Product) error {
+
+	return psql.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+
+		if previous != nil && product.Price < previous.LastPrice {
+			if err := tx.Exec(`INSERT INTO productalert (userid, listid, itemid, alerttype, oldprice, newprice)
+				SELECT favoritelist.userid, favoriteitem.listid, favoriteitem.itemid, ?, favoriteitem.favoritedprice, ?
+				FROM favoriteitem JOIN favoritelist ON favoritelist.id = favoriteitem.listid
+				WHERE favoriteitem.itemid = ? AND favoriteitem.favoritedprice > ?`,
+				models.AlertTypePriceDrop, product.Price, product.ID, product.Price).Error; err != nil {
+				return err
+			}
+		}
+
+		if previous != nil && previous.LastStock <= 0 && product.Stock > 0 {
+			if err := tx.Exec(`INSERT INTO productalert (userid, listid, itemid, alerttype, oldstock, newstock)
+				SELECT favoritelist.userid, favoriteitem.listid, favoriteitem.itemid, ?, ?, ?
+				FROM favoriteitem JOIN favoritelist ON favoritelist.id = favoriteitem.listid
+				WHERE favoriteitem.itemid = ?`,
+				models.AlertTypeBackInStock, previous.LastStock, product.Stock, product.ID).Error; err != nil {
+				return err
+			}
+		}
+
+		// Eklenirken ürün servisine ulaşılamadığı için fiyat/stok bilgisi boş kalan kayıtlar
+		// güncel değerlerle doldurulur; aksi halde bu kayıtlar için hiç alarm üretilmez.
+		// Bu alanlar liste yanıtında döndüğünden etkilenen listelerin versiyonu (ETag) da artırılır.
+		if err := tx.Table("favoritelist").
+			Where("id IN (?)", tx.Table("favoriteitem").Select("listid").Where("itemid = ? AND favoritedprice IS NULL", product.ID)).
+			Updates(map[string]interface{}{"version": gorm.Expr("version + 1"), "updateddate": time.Now()}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Table("favoriteitem").
+			Where("itemid = ? AND favoritedprice IS NULL", product.ID).
+			Updates(map[string]interface{}{"favoritedprice": product.Price, "favoritedstock": product.Stock}).Error; err != nil {
+			return err
+		}
+
+		watch := models.ProductWatch{
+			ItemId:      product.ID,
+			LastPrice:   product.Price,
+			LastStock:   product.Stock,
+			CheckedDate: time.Now(),
+		}
+
+		return tx.Table("productwatch").Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "itemid"}},
+			DoUpdates: clause.AssignmentColumns([]string{"lastprice", "laststock", "checkeddate"}),
+		}).Create(&watch).Error
+	})
+
+}
+
+func (r *ProductWatchRepository) GetUserAlerts(ctx context.Context, userId int, limit int) ([]models.ProductAlert, error) {
+
+	var alerts []models.ProductAlert
+
+	if err := psql.Conn(ctx, r.db).Table("productalert").
+		Where("userid = ?", userId).
+		Order("createddate DESC, id DESC").
+		Limit(limit).
+		Find(&alerts).Error; err != nil {
+		return nil, err
+	}
+
+	return alerts, nil
+
+}
@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"favorite_service/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductWatchRepository(t *testing.T) {
+
+	favoriteItemRepository := NewFavoriteItemRepository(db)
+	productWatchRepository := NewProductWatchRepository(db)
+
+	price := 100.0
+	stock := 0
+
+	_, err := favoriteItemRepository.CreateFavoriteItem(ctx, models.FavoriteItem{
+		ItemId:         7,
+		ListId:         2,
+		FavoritedPrice: &price,
+		FavoritedStock: &stock,
+	})
+
+	assert.Nil(t, err)
+
+	t.Run("TestGetFavoritedItemIds", func(t *testing.T) {
+
+		itemIds, err := productWatchRepository.GetFavoritedItemIds(ctx, 6, 10)
+
+		assert.Nil(t, err)
+
+		assert.Contains(t, itemIds, 7)
+
+	})
+
+	t.Run("TestSaveProductWatchCreatesAlerts", func(t *testing.T) {
+
+		watches, err := productWatchRepository.GetProductWatches(ctx, []int{7})
+
+		assert.Nil(t, err)
+
+		assert.Len(t, watches, 1)
+
+		err = productWatchRepository.SaveProductWatch(ctx, &watches[0], models.Product{ID: 7, Price: 80, Stock: 5})
+
+		assert.Nil(t, err)
+
+		alerts, err := productWatchRepository.GetUserAlerts(ctx, 2, 10)
+
+		assert.Nil(t, err)
+
+		alertTypes := []string{}
+		for _, alert := range alerts {
+			alertTypes = append(alertTypes, alert.AlertType)
+		}
+
+		assert.Contains(t, alertTypes, models.AlertTypePriceDrop)
+
+		assert.Contains(t, alertTypes, models.AlertTypeBackInStock)
+
+	})
+
+	t.Run("TestSaveProductWatchBackfillBumpsListVersion", func(t *testing.T) {
+
+		favoriteListRepository := NewFavoriteListRepository(db)
+
+		_, err := favoriteItemRepository.CreateFavoriteItem(ctx, models.FavoriteItem{ItemId: 8, ListId: 2})
+
+		assert.Nil(t, err)
+
+		before, err := favoriteListRepository.GetListOwner(ctx, 2)
+
+		assert.Nil(t, err)
+
+		err = productWatchRepository.SaveProductWatch(ctx, nil, models.Product{ID: 8, Price: 50, Stock: 3})
+
+		assert.Nil(t, err)
+
+		after, err := favoriteListRepository.GetListOwner(ctx, 2)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, before.Version+1, after.Version)
+
+	})
+
+}
@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+	"favorite_service/internal/models"
+	"favorite_service/pkg/psql"
+
+	"gorm.io/gorm"
+)
+
+// quotaLockNamespace pg_advisory_xact_lock çağrılarında kullanıcı liste kotası kilitlerini
+// diğer advisory kilitlerden ayırır.
+const quotaLockNamespace = 4401
+
+type QuotaRepository struct {
+	db *gorm.DB
+}
+
+func NewQuotaRepository(db *gorm.DB) *QuotaRepository {
+	return &QuotaRepository{
+		db: db,
+	}
+}
+
+// LockAndCountUserLists kullanıcının liste oluşturmasını transaction sonuna kadar kilitler ve
+// mevcut liste sayısını döner. Transaction içinde çağrılmalıdır.
+func (r *QuotaRepository) LockAndCountUserLists(ctx context.Context, userId int) (int, error) {
+
+	conn := psql.Conn(ctx, r.db)
+
+	if err := conn.Exec("SELECT pg_advisory_xact_lock(?, ?)", quotaLockNamespace, userId).Error; err != nil {
+		return 0, err
+	}
+
+	var count int64
+
+	if err := conn.Table("favoritelist").Where("userid = ?", userId).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+
+}
+
+// LockAndCountListItems listeyi FOR UPDATE ile kilitler ve içindeki ürün sayısını döner.
+// Transaction içinde çağrılmalıdır.
+func (r *QuotaRepository) LockAndCountListItems(ctx context.Context, listId int) (int, error) {
+
+	conn := psql.Conn(ctx, r.db)
+
+	var lockedId int
+
+	if err := conn.Raw("SELECT id FROM favoritelist WHERE id = ? FOR UPDATE", listId).Scan(&lockedId).Error; err != nil {
+		return 0, err
+	}
+
+	var count int64
+
+	if err := conn.Table("favoriteitem").Where("listid = ?", listId).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+
+}
+
+func (r *QuotaRepository) CountUserLists(ctx context.Context, userId int) (int, error) {
+
+	var count int64
+
+	if err := psql.Conn(ctx, r.db).Table("favoritelist").Where("userid = ?", userId).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+
+}
+
+func (r *QuotaRepository) GetListItemCounts(ctx context.Context, userId int) ([]models.ListQuotaUsage, error) {
+
+	var usages []models.ListQuotaUsage
+
+	if err := psql.Conn(ctx, r.db).Table("favoritelist").
+		Select("favoritelist.id AS listid, favoritelist.listname, COUNT(favoriteitem.itemid) AS used").
+		Joins("LEFT JOIN favoriteitem ON favoriteitem.listid = favoritelist.id").
+		Where("favoritelist.userid = ?", userId).
+		Group("favoritelist.id, favoritelist.listname").
+		Order("favoritelist.id").
+		Find(&usages).Error; err != nil {
+		return nil, err
+	}
+
+	return usages, nil
+
+}
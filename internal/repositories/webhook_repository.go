@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"context"
+	"favorite_service/internal/models"
+	"favorite_service/pkg/psql"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) *WebhookRepository {
+	return &WebhookRepository{
+		db: db,
+	}
+}
+
+func (r *WebhookRepository) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+
+	return psql.Conn(ctx, r.db).Table("webhooksubscription").Create(subscription).Error
+
+}
+
+func (r *WebhookRepository) GetSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+
+	var subscriptions []models.WebhookSubscription
+
+	if err := psql.Conn(ctx, r.db).Table("webhooksubscription").Order("id").Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+
+}
+
+func (r *WebhookRepository) GetSubscription(ctx context.Context, subscriptionId int) (models.WebhookSubscription, error) {
+
+	var subscription models.WebhookSubscription
+
+	if err := psql.Conn(ctx, r.db).Table("webhooksubscription").Where("id = ?", subscriptionId).First(&subscription).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return models.WebhookSubscription{}, models.ErrRecordNotFound
+		}
+		return models.WebhookSubscription{}, err
+	}
+
+	return subscription, nil
+
+}
+
+func (r *WebhookRepository) DeleteSubscription(ctx context.Context, subscriptionId int) error {
+
+	result := psql.Conn(ctx, r.db).Table("webhooksubscription").Where("id = ?", subscriptionId).Delete(&models.WebhookSubscription{})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return models.ErrRecordNotFound
+	}
+
+	return nil
+
+}
+
+func (r *WebhookRepository) GetActiveSubscriptionsForEvent(ctx context.Context, eventType string) ([]models.WebhookSubscription, error) {
+
+	var subscriptions []models.WebhookSubscription
+
+	if err := psql.Conn(ctx, r.db).Table("webhooksubscription").
+		Where("active AND ? = ANY(string_to_array(eventtypes, ','))", eventType).
+		Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+
+}
+
+// CreateDeliveries aynı event tekrar yayınlandığında (subscriptionid, eventid) tekilliği
+// sayesinde ikinci bir teslimat oluşturmaz.
+func (r *WebhookRepository) CreateDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error {
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	return psql.Conn(ctx, r.db).Table("webhookdelivery").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&deliveries).Error
+
+}
+
+// ClaimDueDeliveries zamanı gelen teslimatları tek bir UPDATE ile sahiplenir: nextattemptdate
+// leaseUntil'e ötelenir ve sorgu commit edildiği anda satır kilitleri bırakılır. HTTP gönderimi
+// transaction dışında yapılır; worker sonucu yazamadan durursa teslimat lease sonunda tekrar alınır.
+func (r *WebhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, leaseUntil time.Time) ([]models.WebhookDelivery, error) {
+
+	var deliveries []models.WebhookDelivery
+
+	if err := psql.Conn(ctx, r.db).Raw(`UPDATE webhookdelivery SET nextattemptdate = ?, updateddate = ?
+		WHERE id IN (
+			SELECT id FROM webhookdelivery
+			WHERE status = ? AND nextattemptdate <= ?
+			ORDER BY nextattemptdate, id
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED)
+		RETURNING *`,
+		leaseUntil, time.Now(), models.WebhookDeliveryPending, time.Now(), limit).Scan(&deliveries).Error; err != nil {
+		return nil, err
+	}
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].Id < deliveries[j].Id
+	})
+
+	return deliveries, nil
+
+}
+
+func (r *WebhookRepository) UpdateDelivery(ctx context.Context, delivery models.WebhookDelivery) error {
+
+	return psql.Conn(ctx, r.db).Table("webhookdelivery").Where("id = ?", delivery.Id).
+		Updates(map[string]interface{}{
+			"status":          delivery.Status,
+			"attempts":        delivery.Attempts,
+			"nextattemptdate": delivery.NextAttemptDate,
+			"laststatuscode":  delivery.LastStatusCode,
+			"lasterror":       delivery.LastError,
+			"updateddate":     time.Now(),
+		}).Error
+
+}
+
+func (r *WebhookRepository) GetDeliveries(ctx context.Context, subscriptionId int, limit int) ([]models.WebhookDelivery, error) {
+
+	var deliveries []models.WebhookDelivery
+
+	if err := psql.Conn(ctx, r.db).Table("webhookdelivery").
+		Where("subscriptionid = ?", subscriptionId).
+		Order("createddate DESC, id DESC").
+		Limit(limit).
+		Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+
+}
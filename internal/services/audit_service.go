@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"favorite_service/internal/models"
+	"favorite_service/pkg/requestid"
+)
+
+type auditRecorder interface {
+	AddAuditEntry(ctx context.Context, entry *models.AuditEntry) error
+}
+
+type auditRepository interface {
+	GetAuditEntries(ctx context.Context, filter models.AuditLogFilter, limit int) ([]models.AuditEntry, error)
+}
+
+// addAuditEntry before ve after görüntülerini JSON'a çevirip isteğin id'si ile birlikte yazar.
+// Değişiklikle aynı transaction içinde çağrılmalıdır. nil görüntüler boş bırakılır.
+func addAuditEntry(ctx context.Context, recorder auditRecorder, entry models.AuditEntry, before interface{}, after interface{}) error {
+
+	var err error
+
+	if before != nil {
+		if entry.Before, err = json.Marshal(before); err != nil {
+			return err
+		}
+	}
+
+	if after != nil {
+		if entry.After, err = json.Marshal(after); err != nil {
+			return err
+		}
+	}
+
+	entry.RequestId = requestid.FromContext(ctx)
+
+	return recorder.AddAuditEntry(ctx, &entry)
+}
+
+type AuditService struct {
+	repo auditRepository
+}
+
+func NewAuditService(repo auditRepository) *AuditService {
+	return &AuditService{
+		repo: repo,
+	}
+}
+
+// GetAuditLog bir sonraki sayfanın olup olmadığını anlamak için limit+1 kayıt okur.
+func (s *AuditService) GetAuditLog(ctx context.Context, filter models.AuditLogFilter) (models.AuditLogPage, error) {
+
+	entries, err := s.repo.GetAuditEntries(ctx, filter, filter.Limit+1)
+	if err != nil {
+		return models.AuditLogPage{}, err
+	}
+
+	page := models.AuditLogPage{Entries: entries}
+
+	if len(entries) > filter.Limit {
+		page.Entries = entries[:filter.Limit]
+		nextCursor := page.Entries[len(page.Entries)-1].Id
+		page.NextCursor = &nextCursor
+	}
+
+	if page.Entries == nil {
+		page.Entries = []models.AuditEntry{}
+	}
+
+	return page, nil
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"favorite_service/internal/models"
+	"sync"
+	"time"
+)
+
+type favoriteCountRepository interface {
+	GetDistinctUserFavoriteCounts(ctx context.Context, itemIds []int) ([]models.ProductFavoriteCount, error)
+}
+
+type favoriteCountEntry struct {
+	count     int64
+	expiresAt time.Time
+}
+
+type FavoriteCountService struct {
+	repo favoriteCountRepository
+	ttl  time.Duration
+
+	mu    sync.RWMutex
+	cache map[int]favoriteCountEntry
+	// generation her invalidation'da artar; sorgu sürerken gelen bir invalidation
+	// varsa okunan eski sayılar önbelleğe yazılmaz.
+	generation uint64
+}
+
+func NewFavoriteCountService(repo favoriteCountRepository, ttl time.Duration) *FavoriteCountService {
+	return &FavoriteCountService{
+		repo:  repo,
+		ttl:   ttl,
+		cache: make(map[int]favoriteCountEntry),
+	}
+}
+
+func (s *FavoriteCountService) GetFavoriteCounts(ctx context.Context, productIds []int) ([]models.ProductFavoriteCount, error) {
+
+	now := time.Now()
+	counts := make(map[int]int64, len(productIds))
+	var missing []int
+
+	s.mu.RLock()
+	generation := s.generation
+	for _, productId := range productIds {
+		entry, ok := s.cache[productId]
+		if ok && now.Before(entry.expiresAt) {
+			counts[productId] = entry.count
+			continue
+		}
+		missing = append(missing, productId)
+	}
+	s.mu.RUnlock()
+
+	if len(missing) > 0 {
+		fetched, err := s.repo.GetDistinctUserFavoriteCounts(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, productId := range missing {
+			counts[productId] = 0
+		}
+		for _, count := range fetched {
+			counts[count.ItemId] = count.FavoriteCount
+		}
+
+		expiresAt := now.Add(s.ttl)
+
+		s.mu.Lock()
+		if s.generation == generation {
+			for _, productId := range missing {
+				s.cache[productId] = favoriteCountEntry{count: counts[productId], expiresAt: expiresAt}
+			}
+		}
+		s.mu.Unlock()
+	}
+
+	response := make([]models.ProductFavoriteCount, 0, len(productIds))
+	for _, productId := range productIds {
+		response = append(response, models.ProductFavoriteCount{
+			ItemId:        productId,
+			FavoriteCount: counts[productId],
+		})
+	}
+
+	return response, nil
+}
+
+func (s *FavoriteCountService) Invalidate(productIds ...int) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.generation++
+	for _, productId := range productIds {
+		delete(s.cache, productId)
+	}
+}
+
+func (s *FavoriteCountService) InvalidateAll() {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.generation++
+	s.cache = make(map[int]favoriteCountEntry)
+}
@@ -2,46 +2,77 @@ package services
 
 import (
 	"context"
+	"errors"
+	"favorite_service/internal/events"
 	"favorite_service/internal/models"
-	"sync"
+	"favorite_service/logs"
+	"favorite_service/metrics"
+	"fmt"
+	"time"
 )
 
 type favoriItemRepository interface {
 	GetFavoriteItem(ctx context.Context, listId int) ([]models.FavoriteItem, error)
-	CreateFavoriteItem(ctx context.Context, favoriteItem models.CreateFavoriteItem) (models.FavoriteItem, error)
+	CreateFavoriteItem(ctx context.Context, favoriteItem models.FavoriteItem) (models.FavoriteItem, error)
 	DeleteFavoriteItem(ctx context.Context, listId int, itemId int) error
+	GetUserFavoritesByItemIds(ctx context.Context, userId int, itemIds []int) ([]models.FavoriteItemMembership, error)
+	SetFavoriteItemPositions(ctx context.Context, listId int, itemIds []int) error
+	PatchFavoriteItem(ctx context.Context, listId int, itemId int, patch models.PatchFavoriteItem) (models.FavoriteItem, error)
+	GetFavoriteItemForUpdate(ctx context.Context, listId int, itemId int) (models.FavoriteItem, error)
 }
 
 type listRepository interface {
 	GetListOwner(ctx context.Context, listId int) (models.FavoriteList, error)
+	GetDefaultListForUpdate(ctx context.Context, userId int) (models.FavoriteList, error)
+	GetOrCreateDefaultList(ctx context.Context, userId int, listName string) (models.FavoriteList, bool, error)
 }
 
 type favoriteItemProductClient interface {
 	VerifyProduct(ctx context.Context, productId int) (*models.Product, error)
+	GetProduct(ctx context.Context, productId int) (*models.Product, error)
 }
 
+// productSnapshotTimeout ürün eklenirken fiyat/stok bilgisinin alınması için beklenen en uzun süredir.
+// Bu süre içinde cevap gelmezse ürün bilgisiz eklenir, bilgi ürün takip işi tarafından sonradan doldurulur.
+const productSnapshotTimeout = 2 * time.Second
+
 type favoriteItemUserClient interface {
 	VerifyUser(token string, ctx context.Context) (*models.Users, error)
 }
 
+type favoriteCountInvalidator interface {
+	Invalidate(productIds ...int)
+}
+
 type FavoriItemService struct {
 	favoriItemRepository favoriItemRepository
 	listRepository       listRepository
 	productClient        favoriteItemProductClient
 	userClient           favoriteItemUserClient
+	countCache           favoriteCountInvalidator
+	transactor           transactor
+	outbox               eventOutbox
+	quotas               quotaEnforcer
+	audit                auditRecorder
 }
 
 func NewFavoriItemService(favoriItemRepository favoriItemRepository, lislistRepository listRepository,
-	productClient favoriteItemProductClient, userClient favoriteItemUserClient) *FavoriItemService {
+	productClient favoriteItemProductClient, userClient favoriteItemUserClient, countCache favoriteCountInvalidator,
+	transactor transactor, outbox eventOutbox, quotas quotaEnforcer, audit auditRecorder) *FavoriItemService {
 	return &FavoriItemService{
 		favoriItemRepository: favoriItemRepository,
 		listRepository:       lislistRepository,
 		productClient:        productClient,
 		userClient:           userClient,
+		countCache:           countCache,
+		transactor:           transactor,
+		outbox:               outbox,
+		quotas:               quotas,
+		audit:                audit,
 	}
 }
 
-func (s *FavoriItemService) GetFavoriteItem(listId int, token string, ctx context.Context) ([]models.Product, error) {
+func (s *FavoriItemService) GetFavoriteItem(listId int, token string, ctx context.Context) ([]models.FavoriteProduct, error) {
 
 	user, err := s.userClient.VerifyUser(token, ctx)
 
@@ -70,6 +101,66 @@ func (s *FavoriItemService) GetFavoriteItem(listId int, token string, ctx contex
 
 }
 
+// GetFavoriteItems listedeki ürünleri include ile istenen parçalarla döner.
+func (s *FavoriItemService) GetFavoriteItems(listId int, include models.ResponseInclude, token string, ctx context.Context) (models.FavoriteItemsResponse, error) {
+
+	user, err := s.userClient.VerifyUser(token, ctx)
+
+	if err != nil {
+		return models.FavoriteItemsResponse{}, err
+	}
+
+	ownerFavoriteList, err := s.listRepository.GetListOwner(ctx, listId)
+
+	if err != nil {
+		return models.FavoriteItemsResponse{}, err
+	}
+
+	if ownerFavoriteList.UserId != user.ID {
+		return models.FavoriteItemsResponse{}, models.ErrunaUthorizedAction
+	}
+
+	favoriteItems, err := s.favoriItemRepository.GetFavoriteItem(ctx, listId)
+	if err != nil {
+		return models.FavoriteItemsResponse{}, err
+	}
+
+	response := models.FavoriteItemsResponse{ListId: listId}
+
+	if include.ItemIds {
+		response.ItemIds = favoriteItemIds(favoriteItems)
+	}
+
+	if include.Counts {
+		count := len(favoriteItems)
+		response.ItemCount = &count
+	}
+
+	if include.Products {
+		products, err := GetProductInfo(ctx, favoriteItems, s.productClient)
+		if err != nil {
+			return models.FavoriteItemsResponse{}, err
+		}
+
+		response.Products = products
+	}
+
+	return response, nil
+}
+
+func favoriteItemIds(items []models.FavoriteItem) []int {
+
+	ids := make([]int, 0, len(items))
+
+	for _, item := range items {
+		ids = append(ids, item.ItemId)
+	}
+
+	return ids
+}
+
+// CreateFavoriteItem ürünü listeye ekler. ListId verilmezse ürün kullanıcının
+// varsayılan listesine eklenir; varsayılan liste yoksa oluşturulur.
 func (s *FavoriItemService) CreateFavoriteItem(item models.CreateFavoriteItem, token string, ctx context.Context) (models.FavoriteItem, error) {
 
 	user, err := s.userClient.VerifyUser(token, ctx)
@@ -80,6 +171,10 @@ func (s *FavoriItemService) CreateFavoriteItem(item models.CreateFavoriteItem, t
 
 	}
 
+	if item.ListId == 0 {
+		return s.addFavoriteItem(ctx, user.ID, item)
+	}
+
 	ownerFavoriteList, err := s.listRepository.GetListOwner(ctx, item.ListId)
 
 	if err != nil {
@@ -92,7 +187,227 @@ func (s *FavoriItemService) CreateFavoriteItem(item models.CreateFavoriteItem, t
 
 	}
 
-	return s.favoriItemRepository.CreateFavoriteItem(ctx, item)
+	return s.addFavoriteItem(ctx, user.ID, item)
+}
+
+// addFavoriteItem ürünü listeye ekler. item.ListId 0 ise ürün aynı transaction içinde kullanıcının
+// varsayılan listesine eklenir.
+func (s *FavoriItemService) addFavoriteItem(ctx context.Context, userId int, item models.CreateFavoriteItem) (models.FavoriteItem, error) {
+
+	favoriteItem := newFavoriteItem(item)
+
+	if err := s.fillProductSnapshot(ctx, &favoriteItem); err != nil {
+		return models.FavoriteItem{}, err
+	}
+
+	listCreated := false
+
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+
+		if favoriteItem.ListId == 0 {
+
+			defaultList, created, err := s.lockDefaultList(ctx, userId)
+			if err != nil {
+				return err
+			}
+
+			favoriteItem.ListId = defaultList.Id
+			listCreated = created
+		}
+
+		return s.insertFavoriteItem(ctx, userId, &favoriteItem)
+	})
+
+	if err != nil {
+		return models.FavoriteItem{}, err
+	}
+
+	if listCreated {
+		metrics.FavoriteListsCreated.Inc()
+	}
+
+	s.countCache.Invalidate(favoriteItem.ItemId)
+
+	metrics.FavoriteItemsAdded.Inc()
+
+	return favoriteItem, nil
+}
+
+func newFavoriteItem(item models.CreateFavoriteItem) models.FavoriteItem {
+
+	favoriteItem := models.FavoriteItem{
+		ItemId:   item.ItemId,
+		ListId:   item.ListId,
+		Note:     item.Note,
+		Quantity: item.Quantity,
+		Priority: item.Priority,
+		Variant:  item.Variant,
+	}
+
+	if favoriteItem.Quantity == 0 {
+		favoriteItem.Quantity = 1
+	}
+
+	if favoriteItem.Priority == "" {
+		favoriteItem.Priority = models.PriorityNiceToHave
+	}
+
+	return favoriteItem
+}
+
+// fillProductSnapshot ürünün favorilendiği andaki fiyat ve stok bilgisini doldurur.
+// Yazma yolunda tekrar deneme yapılmaz: tek bir kısa süreli istek atılır, ürün yoksa hemen hata döner.
+func (s *FavoriItemService) fillProductSnapshot(ctx context.Context, favoriteItem *models.FavoriteItem) error {
+
+	lookupCtx, cancel := context.WithTimeout(ctx, productSnapshotTimeout)
+	product, err := s.productClient.GetProduct(lookupCtx, favoriteItem.ItemId)
+	cancel()
+
+	switch {
+	case errors.Is(err, models.ErrRecordNotFound):
+		return fmt.Errorf("ürün %d: %w", favoriteItem.ItemId, models.ErrProductNotFound)
+	case err != nil:
+		logs.Warning(fmt.Sprintf("Ürün %d için fiyat/stok bilgisi alınamadı: %v", favoriteItem.ItemId, err),
+			logs.WithHandlerName("FavoriItemService_CreateFavoriteItem"),
+		)
+	default:
+		favoriteItem.FavoritedPrice = &product.Price
+		favoriteItem.FavoritedStock = &product.Stock
+	}
+
+	return nil
+}
+
+// insertFavoriteItem ürünü kota kontrolü, audit kaydı ve outbox olayıyla birlikte ekler. Transaction içinde çağrılmalıdır.
+func (s *FavoriItemService) insertFavoriteItem(ctx context.Context, userId int, favoriteItem *models.FavoriteItem) error {
+
+	if err := s.quotas.CheckItemQuota(ctx, favoriteItem.ListId); err != nil {
+		return err
+	}
+
+	created, err := s.favoriItemRepository.CreateFavoriteItem(ctx, *favoriteItem)
+	if err != nil {
+		return err
+	}
+
+	*favoriteItem = created
+
+	if err := addAuditEntry(ctx, s.audit, itemAuditEntry(userId, models.AuditActionCreate, created.ListId, created.ItemId), nil, created); err != nil {
+		return err
+	}
+
+	return addOutboxEvent(ctx, s.outbox, events.TypeFavoriteItemAdded, events.FavoriteItemEventData{
+		UserId: userId,
+		ListId: created.ListId,
+		ItemId: created.ItemId,
+	})
+}
+
+// lockDefaultList kullanıcının varsayılan listesini kilitleyerek döner. Liste yoksa liste kotası kontrol
+// edilerek oluşturulur; oluşturma normal liste oluşturma gibi audit kaydı ve favorite_list.created olayı yazar.
+// Transaction içinde çağrılmalıdır; liste oluşturulduysa metrik, çağıran tarafından commit sonrası artırılır.
+func (s *FavoriItemService) lockDefaultList(ctx context.Context, userId int) (models.FavoriteList, bool, error) {
+
+	defaultList, err := s.listRepository.GetDefaultListForUpdate(ctx, userId)
+	if !errors.Is(err, models.ErrRecordNotFound) {
+		return defaultList, false, err
+	}
+
+	if err := s.quotas.CheckListQuota(ctx, userId); err != nil {
+		return models.FavoriteList{}, false, err
+	}
+
+	defaultList, created, err := s.listRepository.GetOrCreateDefaultList(ctx, userId, models.DefaultListName)
+	if err != nil || !created {
+		return defaultList, false, err
+	}
+
+	if err := addAuditEntry(ctx, s.audit, listAuditEntry(userId, models.AuditActionCreate, defaultList.Id), nil, defaultList); err != nil {
+		return models.FavoriteList{}, false, err
+	}
+
+	if err := addOutboxEvent(ctx, s.outbox, events.TypeFavoriteListCreated, events.FavoriteListEventData{
+		UserId:   userId,
+		ListId:   defaultList.Id,
+		ListName: defaultList.ListName,
+	}); err != nil {
+		return models.FavoriteList{}, false, err
+	}
+
+	return defaultList, true, nil
+}
+
+// ToggleDefaultFavorite ürün varsayılan listede varsa çıkarır, yoksa ekler. Varsayılan listenin oluşturulması,
+// üyelik kontrolü ve ekleme/çıkarma tek transaction içinde yapılır; liste satırı kilitlendiği için aynı
+// kullanıcının eşzamanlı dokunuşları sırayla uygulanır.
+func (s *FavoriItemService) ToggleDefaultFavorite(productId int, token string, ctx context.Context) (models.FavoriteToggleResponse, error) {
+
+	user, err := s.userClient.VerifyUser(token, ctx)
+
+	if err != nil {
+		return models.FavoriteToggleResponse{}, err
+	}
+
+	favoriteItem := newFavoriteItem(models.CreateFavoriteItem{ItemId: productId})
+
+	// Ürün bilgisi kilit alınmadan önce her zaman çekilir; çıkarma yolunda kullanılmaz. Ürün bulunamadıysa
+	// yalnızca ekleme reddedilir, silinmiş bir ürün favorilerden yine çıkarılabilir.
+	snapshotErr := s.fillProductSnapshot(ctx, &favoriteItem)
+
+	response := models.FavoriteToggleResponse{
+		ProductId: productId,
+	}
+
+	listCreated := false
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+
+		defaultList, created, err := s.lockDefaultList(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+
+		response.ListId = defaultList.Id
+		listCreated = created
+
+		memberships, err := s.favoriItemRepository.GetUserFavoritesByItemIds(ctx, user.ID, []int{productId})
+		if err != nil {
+			return err
+		}
+
+		for _, membership := range memberships {
+			if membership.ListId == defaultList.Id {
+				return s.deleteFavoriteItem(ctx, user.ID, defaultList.Id, productId)
+			}
+		}
+
+		if snapshotErr != nil {
+			return snapshotErr
+		}
+
+		favoriteItem.ListId = defaultList.Id
+		response.Favorited = true
+
+		return s.insertFavoriteItem(ctx, user.ID, &favoriteItem)
+	})
+
+	if err != nil {
+		return models.FavoriteToggleResponse{}, err
+	}
+
+	if listCreated {
+		metrics.FavoriteListsCreated.Inc()
+	}
+
+	s.countCache.Invalidate(productId)
+
+	if response.Favorited {
+		metrics.FavoriteItemsAdded.Inc()
+	} else {
+		metrics.FavoriteItemsRemoved.Inc()
+	}
+
+	return response, nil
 }
 
 func (s *FavoriItemService) DeleteFavoriteItem(listId int, itemId int, token string, ctx context.Context) error {
@@ -115,58 +430,235 @@ func (s *FavoriItemService) DeleteFavoriteItem(listId int, itemId int, token str
 
 	}
 
-	return s.favoriItemRepository.DeleteFavoriteItem(ctx, listId, itemId)
+	return s.removeFavoriteItem(ctx, user.ID, listId, itemId)
+}
+
+func (s *FavoriItemService) removeFavoriteItem(ctx context.Context, userId int, listId int, itemId int) error {
+
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.deleteFavoriteItem(ctx, userId, listId, itemId)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	s.countCache.Invalidate(itemId)
+
+	metrics.FavoriteItemsRemoved.Inc()
+
+	return nil
+}
+
+// deleteFavoriteItem ürünü audit kaydı ve outbox olayıyla birlikte siler. Transaction içinde çağrılmalıdır.
+func (s *FavoriItemService) deleteFavoriteItem(ctx context.Context, userId int, listId int, itemId int) error {
+
+	before, err := s.favoriItemRepository.GetFavoriteItemForUpdate(ctx, listId, itemId)
+	if err != nil {
+		return err
+	}
+
+	if err := s.favoriItemRepository.DeleteFavoriteItem(ctx, listId, itemId); err != nil {
+		return err
+	}
+
+	if err := addAuditEntry(ctx, s.audit, itemAuditEntry(userId, models.AuditActionDelete, listId, itemId), before, nil); err != nil {
+		return err
+	}
+
+	return addOutboxEvent(ctx, s.outbox, events.TypeFavoriteItemRemoved, events.FavoriteItemEventData{
+		UserId: userId,
+		ListId: listId,
+		ItemId: itemId,
+	})
+}
+
+// PatchFavoriteItem listedeki ürünün not, adet, öncelik ve varyant bilgisini günceller.
+func (s *FavoriItemService) PatchFavoriteItem(listId int, itemId int, patch models.PatchFavoriteItem, token string, ctx context.Context) (models.FavoriteItem, error) {
+
+	user, err := s.userClient.VerifyUser(token, ctx)
+
+	if err != nil {
+		return models.FavoriteItem{}, err
+	}
+
+	ownerFavoriteList, err := s.listRepository.GetListOwner(ctx, listId)
+
+	if err != nil {
+		return models.FavoriteItem{}, err
+	}
+
+	if ownerFavoriteList.UserId != user.ID {
+		return models.FavoriteItem{}, models.ErrunaUthorizedAction
+	}
+
+	var patchedItem models.FavoriteItem
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+
+		before, err := s.favoriItemRepository.GetFavoriteItemForUpdate(ctx, listId, itemId)
+		if err != nil {
+			return err
+		}
+
+		patchedItem, err = s.favoriItemRepository.PatchFavoriteItem(ctx, listId, itemId, patch)
+		if err != nil {
+			return err
+		}
+
+		return addAuditEntry(ctx, s.audit, itemAuditEntry(user.ID, models.AuditActionUpdate, listId, itemId), before, patchedItem)
+	})
+
+	if err != nil {
+		return models.FavoriteItem{}, err
+	}
+
+	return patchedItem, nil
 }
 
-func GetProductInfo(ctx context.Context, items []models.FavoriteItem, productClient favoriteItemProductClient) ([]models.Product, error) {
-	var products []models.Product
-	var wg sync.WaitGroup
-	productChan := make(chan *models.Product, 2)
-	errChan := make(chan error, 2)
-	sm := make(chan struct{}, 2)
+// ReorderFavoriteItems listedeki ürünlerin sırasını değiştirir. itemIds listenin tamamını
+// ya da bir kısmını içerebilir; verilmeyen ürünler mevcut sıralarıyla sona eklenir.
+func (s *FavoriItemService) ReorderFavoriteItems(listId int, itemIds []int, token string, ctx context.Context) ([]models.FavoriteItem, error) {
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
+	user, err := s.userClient.VerifyUser(token, ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ownerFavoriteList, err := s.listRepository.GetListOwner(ctx, listId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if ownerFavoriteList.UserId != user.ID {
+		return nil, models.ErrunaUthorizedAction
+	}
+
+	var reordered []models.FavoriteItem
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+
+		items, err := s.favoriItemRepository.GetFavoriteItem(ctx, listId)
+		if err != nil {
+			return err
+		}
+
+		current := make(map[int]bool, len(items))
 		for _, item := range items {
-			select {
-			case <-ctx.Done():
-				errChan <- ctx.Err()
-				return
-			default:
-				wg.Add(1)
-				sm <- struct{}{}
-				go func(itemId int) {
-					defer wg.Done()
-					defer func() { <-sm }()
-					product, err := productClient.VerifyProduct(ctx, item.ItemId)
-					if err != nil {
-						errChan <- err
-						return
-					}
-					productChan <- product
-				}(item.ItemId)
+			current[item.ItemId] = true
+		}
+
+		requested := make(map[int]bool, len(itemIds))
+		for _, itemId := range itemIds {
+			if !current[itemId] {
+				return models.ErrRecordNotFound
+			}
+
+			if requested[itemId] {
+				return models.ErrDuplicateItem
 			}
 
-			if len(errChan) > 0 {
-				break
+			requested[itemId] = true
+		}
+
+		order := append([]int{}, itemIds...)
+		for _, item := range items {
+			if !requested[item.ItemId] {
+				order = append(order, item.ItemId)
 			}
 		}
-	}()
 
-	go func() {
-		wg.Wait()
-		close(errChan)
-		close(productChan)
-	}()
+		if err := s.favoriItemRepository.SetFavoriteItemPositions(ctx, listId, order); err != nil {
+			return err
+		}
+
+		reordered, err = s.favoriItemRepository.GetFavoriteItem(ctx, listId)
+		if err != nil {
+			return err
+		}
+
+		return addAuditEntry(ctx, s.audit, listAuditEntry(user.ID, models.AuditActionMove, listId), favoriteItemIds(items), order)
+	})
 
-	for product := range productChan {
-		products = append(products, *product)
+	if err != nil {
+		return nil, err
 	}
 
-	for err := range errChan {
+	return reordered, nil
+}
+
+func (s *FavoriItemService) ContainsFavoriteItems(productIds []int, token string, ctx context.Context) ([]models.FavoriteContainsResponse, error) {
+
+	user, err := s.userClient.VerifyUser(token, ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	memberships, err := s.favoriItemRepository.GetUserFavoritesByItemIds(ctx, user.ID, productIds)
+	if err != nil {
 		return nil, err
 	}
 
+	listsByProduct := make(map[int][]models.FavoriteListRef)
+	for _, membership := range memberships {
+		listsByProduct[membership.ItemId] = append(listsByProduct[membership.ItemId], models.FavoriteListRef{
+			ListId:   membership.ListId,
+			ListName: membership.ListName,
+		})
+	}
+
+	response := make([]models.FavoriteContainsResponse, 0, len(productIds))
+	for _, productId := range productIds {
+		lists := listsByProduct[productId]
+		if lists == nil {
+			lists = []models.FavoriteListRef{}
+		}
+
+		response = append(response, models.FavoriteContainsResponse{
+			ProductId: productId,
+			Favorited: len(lists) > 0,
+			Lists:     lists,
+		})
+	}
+
+	return response, nil
+}
+
+// GetProductInfo ürünleri product servisinden zenginleştirir ve items sırasını korur.
+func GetProductInfo(ctx context.Context, items []models.FavoriteItem, productClient favoriteListProductClient) ([]models.FavoriteProduct, error) {
+
+	enriched, err := enrichProducts(ctx, productClient, favoriteItemIds(items), 2)
+	if err != nil {
+		return nil, err
+	}
+
+	products := make([]models.FavoriteProduct, 0, len(items))
+	for _, item := range items {
+		products = append(products, newFavoriteProduct(enriched[item.ItemId], item))
+	}
+
 	return products, nil
 }
+
+func newFavoriteProduct(product models.Product, item models.FavoriteItem) models.FavoriteProduct {
+	return models.FavoriteProduct{
+		Product:  product,
+		Note:     item.Note,
+		Quantity: item.Quantity,
+		Priority: item.Priority,
+		Variant:  item.Variant,
+	}
+}
+
+func itemAuditEntry(actorUserId int, action string, listId int, itemId int) models.AuditEntry {
+	return models.AuditEntry{
+		ActorUserId: actorUserId,
+		EntityType:  models.AuditEntityItem,
+		Action:      action,
+		ListId:      listId,
+		ItemId:      &itemId,
+	}
+}
@@ -2,19 +2,28 @@ package services
 
 import (
 	"context"
+	"errors"
+	"favorite_service/internal/events"
 	"favorite_service/internal/models"
+	"favorite_service/metrics"
 )
 
 type favoriteListRepository interface {
 	GetFavoriteList(ctx context.Context, userId int) ([]models.FavoriteList, error)
+	GetFavoriteListsByFilter(ctx context.Context, userId int, filter models.FavoriteListFilter) ([]models.FavoriteList, error)
+	PatchFavoriteList(ctx context.Context, listId int, expectedVersion *int, patch models.PatchFavoriteList) (models.FavoriteList, error)
+	SetDefaultList(ctx context.Context, userId int, listId int) (models.FavoriteList, error)
 	CreateFavoriteList(ctx context.Context, favoriteList *models.FavoriteList) error
-	UpdateFavoriteList(ctx context.Context, listId int, updtList models.UpdateFavoriteList) (models.FavoriteList, error)
-	DeleteFavoriteList(ctx context.Context, listId int) error
+	UpdateFavoriteList(ctx context.Context, listId int, expectedVersion *int, updtList models.UpdateFavoriteList) (models.FavoriteList, error)
+	DeleteFavoriteList(ctx context.Context, listId int, expectedVersion *int) error
 	GetListOwner(ctx context.Context, listId int) (models.FavoriteList, error)
+	GetFavoriteListForUpdate(ctx context.Context, listId int) (models.FavoriteList, error)
+	GetDefaultListForUpdate(ctx context.Context, userId int) (models.FavoriteList, error)
 }
 
 type favoriteItemRepository interface {
 	GetFavoriteItem(ctx context.Context, listId int) ([]models.FavoriteItem, error)
+	GetFavoriteItemsByUserId(ctx context.Context, userId int) ([]models.FavoriteItem, error)
 	DeleteFavoriteItemsByListId(ctx context.Context, listId int) error
 }
 
@@ -26,64 +35,192 @@ type favoriteListUserClient interface {
 	VerifyUser(token string, ctx context.Context) (*models.Users, error)
 }
 
+type favoriteListCountInvalidator interface {
+	InvalidateAll()
+}
+
 type FavoriteListService struct {
 	listRepo                  favoriteListRepository
 	itemRepo                  favoriteItemRepository
 	favoriteListProductClient favoriteListProductClient
 	favoriteListUserClient    favoriteListUserClient
+	countCache                favoriteListCountInvalidator
+	transactor                transactor
+	outbox                    eventOutbox
+	enrichConcurrency         int
+	quotas                    quotaEnforcer
+	audit                     auditRecorder
 }
 
 func NewFavoriteListService(
 	listRepo favoriteListRepository,
 	itemRepo favoriteItemRepository,
 	favoriteListProductClient favoriteListProductClient,
-	favoriteListUserClient favoriteListUserClient) *FavoriteListService {
+	favoriteListUserClient favoriteListUserClient,
+	countCache favoriteListCountInvalidator,
+	transactor transactor,
+	outbox eventOutbox,
+	enrichConcurrency int,
+	quotas quotaEnforcer,
+	audit auditRecorder) *FavoriteListService {
 
 	return &FavoriteListService{
 		listRepo:                  listRepo,
 		itemRepo:                  itemRepo,
 		favoriteListProductClient: favoriteListProductClient,
 		favoriteListUserClient:    favoriteListUserClient,
+		countCache:                countCache,
+		transactor:                transactor,
+		outbox:                    outbox,
+		enrichConcurrency:         enrichConcurrency,
+		quotas:                    quotas,
+		audit:                     audit,
 	}
 }
 
 func (s *FavoriteListService) GetUserFavoriteListsWithItems(token string, ctx context.Context) ([]models.FavoriteListResponse, error) {
 
+	return s.GetUserFavoriteLists(models.ResponseInclude{Products: true}, models.FavoriteListFilter{}, token, ctx)
+
+}
+
+// GetUserFavoriteLists kullanıcının listelerini include ile istenen parçalarla döner.
+// Tüm listelerin ürünleri tek sorguda okunur, aynı ürün birden fazla listede olsa bile
+// product servisine bir kez gidilir. Ürünler istenmediğinde product servisine hiç istek atılmaz.
+func (s *FavoriteListService) GetUserFavoriteLists(include models.ResponseInclude, filter models.FavoriteListFilter, token string, ctx context.Context) ([]models.FavoriteListResponse, error) {
+
 	user, err := s.favoriteListUserClient.VerifyUser(token, ctx)
 
 	if err != nil {
 		return nil, err
 	}
 
-	var response []models.FavoriteListResponse
-
-	lists, err := s.listRepo.GetFavoriteList(ctx, user.ID)
+	lists, err := s.listRepo.GetFavoriteListsByFilter(ctx, user.ID, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, list := range lists {
-		items, err := s.itemRepo.GetFavoriteItem(ctx, list.Id)
+	itemsByList := make(map[int][]models.FavoriteItem, len(lists))
+	var products map[int]models.Product
+
+	if include.ItemIds || include.Counts || include.Products {
+
+		items, err := s.itemRepo.GetFavoriteItemsByUserId(ctx, user.ID)
 		if err != nil {
 			return nil, err
 		}
 
-		products, err := GetProductInfo(ctx, items, s.favoriteListProductClient)
-		if err != nil {
-			return nil, err
+		productIds := make([]int, 0, len(items))
+		seen := make(map[int]struct{}, len(items))
+
+		for _, item := range items {
+			itemsByList[item.ListId] = append(itemsByList[item.ListId], item)
+
+			if _, ok := seen[item.ItemId]; !ok {
+				seen[item.ItemId] = struct{}{}
+				productIds = append(productIds, item.ItemId)
+			}
 		}
 
-		response = append(response, models.FavoriteListResponse{
-			ListId:   list.Id,
-			ListName: list.ListName,
-			Items:    products,
-		})
+		if include.Products {
+			products, err = enrichProducts(ctx, s.favoriteListProductClient, productIds, s.enrichConcurrency)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var response []models.FavoriteListResponse
+
+	for _, list := range lists {
+
+		items := itemsByList[list.Id]
+
+		listResponse := models.FavoriteListResponse{
+			ListId:               list.Id,
+			ListName:             list.ListName,
+			FavoriteListMetadata: list.FavoriteListMetadata,
+			UpdatedDate:          list.UpdatedDate,
+			IsDefault:            list.IsDefault,
+			Version:              list.Version,
+		}
+
+		if include.ItemIds {
+			listResponse.ItemIds = favoriteItemIds(items)
+		}
+
+		if include.Counts {
+			count := len(items)
+			listResponse.ItemCount = &count
+		}
+
+		if include.Products {
+			listResponse.Items = make([]models.FavoriteProduct, 0, len(items))
+			for _, item := range items {
+				listResponse.Items = append(listResponse.Items, newFavoriteProduct(products[item.ItemId], item))
+			}
+		}
+
+		response = append(response, listResponse)
 	}
 
 	return response, nil
 
 }
 
+// GetFavoriteList tek bir listenin bilgilerini ve ürün sayısını döner. includeProducts
+// verilirse ürünler product servisinden zenginleştirilerek eklenir.
+func (s *FavoriteListService) GetFavoriteList(listId int, includeProducts bool, token string, ctx context.Context) (models.FavoriteListDetail, error) {
+
+	user, err := s.favoriteListUserClient.VerifyUser(token, ctx)
+
+	if err != nil {
+		return models.FavoriteListDetail{}, err
+	}
+
+	list, err := s.listRepo.GetListOwner(ctx, listId)
+
+	if err != nil {
+		return models.FavoriteListDetail{}, err
+	}
+
+	if list.UserId != user.ID {
+		return models.FavoriteListDetail{}, models.ErrunaUthorizedAction
+	}
+
+	items, err := s.itemRepo.GetFavoriteItem(ctx, listId)
+
+	if err != nil {
+		return models.FavoriteListDetail{}, err
+	}
+
+	detail := models.FavoriteListDetail{
+		ListId:               list.Id,
+		ListName:             list.ListName,
+		FavoriteListMetadata: list.FavoriteListMetadata,
+		CreatedDate:          list.CreatedDate,
+		UpdatedDate:          list.UpdatedDate,
+		UserId:               list.UserId,
+		IsDefault:            list.IsDefault,
+		Version:              list.Version,
+		ItemCount:            len(items),
+	}
+
+	if !includeProducts {
+		return detail, nil
+	}
+
+	products, err := GetProductInfo(ctx, items, s.favoriteListProductClient)
+
+	if err != nil {
+		return models.FavoriteListDetail{}, err
+	}
+
+	detail.Items = products
+
+	return detail, nil
+}
+
 func (s *FavoriteListService) CreateFavoriteList(list *models.FavoriteList, token string, ctx context.Context) error {
 
 	user, err := s.favoriteListUserClient.VerifyUser(token, ctx)
@@ -94,10 +231,37 @@ func (s *FavoriteListService) CreateFavoriteList(list *models.FavoriteList, toke
 
 	list.UserId = user.ID
 
-	return s.listRepo.CreateFavoriteList(ctx, list)
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+
+		if err := s.quotas.CheckListQuota(ctx, list.UserId); err != nil {
+			return err
+		}
+
+		if err := s.listRepo.CreateFavoriteList(ctx, list); err != nil {
+			return err
+		}
+
+		if err := addAuditEntry(ctx, s.audit, listAuditEntry(user.ID, models.AuditActionCreate, list.Id), nil, list); err != nil {
+			return err
+		}
+
+		return addOutboxEvent(ctx, s.outbox, events.TypeFavoriteListCreated, events.FavoriteListEventData{
+			UserId:   list.UserId,
+			ListId:   list.Id,
+			ListName: list.ListName,
+		})
+	})
+
+	if err != nil {
+		return err
+	}
+
+	metrics.FavoriteListsCreated.Inc()
+
+	return nil
 }
 
-func (s *FavoriteListService) UpdateFavoriteList(listId int, list models.UpdateFavoriteList, token string, ctx context.Context) (models.FavoriteList, error) {
+func (s *FavoriteListService) UpdateFavoriteList(listId int, list models.UpdateFavoriteList, expectedVersion *int, token string, ctx context.Context) (models.FavoriteList, error) {
 
 	user, err := s.favoriteListUserClient.VerifyUser(token, ctx)
 
@@ -115,10 +279,152 @@ func (s *FavoriteListService) UpdateFavoriteList(listId int, list models.UpdateF
 		return models.FavoriteList{}, models.ErrunaUthorizedAction
 	}
 
-	return s.listRepo.UpdateFavoriteList(ctx, listId, list)
+	var updatedList models.FavoriteList
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+
+		before, err := s.listRepo.GetFavoriteListForUpdate(ctx, listId)
+		if err != nil {
+			return err
+		}
+
+		updatedList, err = s.listRepo.UpdateFavoriteList(ctx, listId, expectedVersion, list)
+		if err != nil {
+			return err
+		}
+
+		if err := addAuditEntry(ctx, s.audit, listAuditEntry(user.ID, models.AuditActionUpdate, listId), before, updatedList); err != nil {
+			return err
+		}
+
+		return addOutboxEvent(ctx, s.outbox, events.TypeFavoriteListUpdated, events.FavoriteListEventData{
+			UserId:   updatedList.UserId,
+			ListId:   updatedList.Id,
+			ListName: updatedList.ListName,
+		})
+	})
+
+	if err != nil {
+		return models.FavoriteList{}, err
+	}
+
+	return updatedList, nil
 }
 
-func (s *FavoriteListService) DeleteFavoriteList(listId int, token string, ctx context.Context) error {
+// PatchFavoriteList listenin yalnızca gönderilen alanlarını günceller.
+func (s *FavoriteListService) PatchFavoriteList(listId int, patch models.PatchFavoriteList, expectedVersion *int, token string, ctx context.Context) (models.FavoriteList, error) {
+
+	user, err := s.favoriteListUserClient.VerifyUser(token, ctx)
+
+	if err != nil {
+		return models.FavoriteList{}, err
+	}
+
+	ownerFavoriteList, err := s.listRepo.GetListOwner(ctx, listId)
+
+	if err != nil {
+		return models.FavoriteList{}, err
+	}
+
+	if ownerFavoriteList.UserId != user.ID {
+		return models.FavoriteList{}, models.ErrunaUthorizedAction
+	}
+
+	var patchedList models.FavoriteList
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+
+		before, err := s.listRepo.GetFavoriteListForUpdate(ctx, listId)
+		if err != nil {
+			return err
+		}
+
+		patchedList, err = s.listRepo.PatchFavoriteList(ctx, listId, expectedVersion, patch)
+		if err != nil {
+			return err
+		}
+
+		if err := addAuditEntry(ctx, s.audit, listAuditEntry(user.ID, models.AuditActionUpdate, listId), before, patchedList); err != nil {
+			return err
+		}
+
+		return addOutboxEvent(ctx, s.outbox, events.TypeFavoriteListUpdated, events.FavoriteListEventData{
+			UserId:   patchedList.UserId,
+			ListId:   patchedList.Id,
+			ListName: patchedList.ListName,
+		})
+	})
+
+	if err != nil {
+		return models.FavoriteList{}, err
+	}
+
+	return patchedList, nil
+}
+
+// SetDefaultList kullanıcının varsayılan listesini değiştirir.
+func (s *FavoriteListService) SetDefaultList(listId int, token string, ctx context.Context) (models.FavoriteList, error) {
+
+	user, err := s.favoriteListUserClient.VerifyUser(token, ctx)
+
+	if err != nil {
+		return models.FavoriteList{}, err
+	}
+
+	ownerFavoriteList, err := s.listRepo.GetListOwner(ctx, listId)
+
+	if err != nil {
+		return models.FavoriteList{}, err
+	}
+
+	if ownerFavoriteList.UserId != user.ID {
+		return models.FavoriteList{}, models.ErrunaUthorizedAction
+	}
+
+	var defaultList models.FavoriteList
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+
+		previousDefault, err := s.listRepo.GetDefaultListForUpdate(ctx, user.ID)
+		if err != nil && !errors.Is(err, models.ErrRecordNotFound) {
+			return err
+		}
+
+		before, err := s.listRepo.GetFavoriteListForUpdate(ctx, listId)
+		if err != nil {
+			return err
+		}
+
+		defaultList, err = s.listRepo.SetDefaultList(ctx, user.ID, listId)
+		if err != nil {
+			return err
+		}
+
+		if err := addAuditEntry(ctx, s.audit, listAuditEntry(user.ID, models.AuditActionUpdate, listId), before, defaultList); err != nil {
+			return err
+		}
+
+		// Varsayılanlıktan çıkarılan liste de değiştiği için ayrıca audit'e yazılır.
+		if previousDefault.Id == 0 || previousDefault.Id == listId {
+			return nil
+		}
+
+		demoted, err := s.listRepo.GetFavoriteListForUpdate(ctx, previousDefault.Id)
+		if err != nil {
+			return err
+		}
+
+		return addAuditEntry(ctx, s.audit, listAuditEntry(user.ID, models.AuditActionUpdate, demoted.Id), previousDefault, demoted)
+	})
+
+	if err != nil {
+		return models.FavoriteList{}, err
+	}
+
+	return defaultList, nil
+}
+
+func (s *FavoriteListService) DeleteFavoriteList(listId int, expectedVersion *int, token string, ctx context.Context) error {
 
 	user, err := s.favoriteListUserClient.VerifyUser(token, ctx)
 
@@ -138,9 +444,57 @@ func (s *FavoriteListService) DeleteFavoriteList(listId int, token string, ctx c
 
 	}
 
-	if err := s.itemRepo.DeleteFavoriteItemsByListId(ctx, listId); err != nil {
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+
+		before, err := s.listRepo.GetFavoriteListForUpdate(ctx, listId)
+		if err != nil {
+			return err
+		}
+
+		items, err := s.itemRepo.GetFavoriteItem(ctx, listId)
+		if err != nil {
+			return err
+		}
+
+		// Boş liste de silinebilmelidir; ürün silinmemesi hata sayılmaz.
+		if err := s.itemRepo.DeleteFavoriteItemsByListId(ctx, listId); err != nil && !errors.Is(err, models.ErrRecordNotFound) {
+			return err
+		}
+
+		if err := s.listRepo.DeleteFavoriteList(ctx, listId, expectedVersion); err != nil {
+			return err
+		}
+
+		snapshot := models.AuditListSnapshot{List: before, Items: items}
+
+		if err := addAuditEntry(ctx, s.audit, listAuditEntry(user.ID, models.AuditActionDelete, listId), snapshot, nil); err != nil {
+			return err
+		}
+
+		return addOutboxEvent(ctx, s.outbox, events.TypeFavoriteListDeleted, events.FavoriteListEventData{
+			UserId: user.ID,
+			ListId: listId,
+		})
+	})
+
+	if err != nil {
 		return err
 	}
 
-	return s.listRepo.DeleteFavoriteList(ctx, listId)
+	// Önbellek transaction commit edildikten sonra temizlenir; aksi halde eşzamanlı bir
+	// okuma henüz silinmemiş ürünlerle eski sayıları tekrar önbelleğe yazabilir.
+	s.countCache.InvalidateAll()
+
+	metrics.FavoriteListsDeleted.Inc()
+
+	return nil
+}
+
+func listAuditEntry(actorUserId int, action string, listId int) models.AuditEntry {
+	return models.AuditEntry{
+		ActorUserId: actorUserId,
+		EntityType:  models.AuditEntityList,
+		Action:      action,
+		ListId:      listId,
+	}
 }
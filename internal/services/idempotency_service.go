@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"favorite_service/internal/models"
+	"favorite_service/logs"
+	"time"
+)
+
+type idempotencyRepository interface {
+	ReserveIdempotencyKey(ctx context.Context, record models.IdempotencyRecord, staleBefore time.Time) (models.IdempotencyRecord, bool, error)
+	CompleteIdempotencyKey(ctx context.Context, scope string, key string, status int, contentType string, body []byte) error
+	ReleaseIdempotencyKey(ctx context.Context, scope string, key string) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error)
+}
+
+type IdempotencyService struct {
+	repo          idempotencyRepository
+	ttl           time.Duration
+	inFlightLimit time.Duration
+}
+
+// NewIdempotencyService ttl süresince tamamlanan yanıtları saklar. inFlightLimit'ten uzun
+// süre in_progress kalan kayıtlar (ör. süreç çöktüyse) yeni bir istek tarafından devralınabilir.
+func NewIdempotencyService(repo idempotencyRepository, ttl time.Duration, inFlightLimit time.Duration) *IdempotencyService {
+	return &IdempotencyService{
+		repo:          repo,
+		ttl:           ttl,
+		inFlightLimit: inFlightLimit,
+	}
+}
+
+// Begin anahtarı bu istek için ayırır ve nil döner. Aynı istek daha önce tamamlandıysa
+// saklanan kayıt döner; anahtar farklı bir istekle kullanıldıysa ErrIdempotencyKeyReused,
+// aynı istek hala işleniyorsa ErrIdempotencyInProgress döner.
+func (s *IdempotencyService) Begin(ctx context.Context, scope string, key string, fingerprint string) (*models.IdempotencyRecord, error) {
+
+	now := time.Now().UTC()
+
+	record, reserved, err := s.repo.ReserveIdempotencyKey(ctx, models.IdempotencyRecord{
+		Scope:          scope,
+		IdempotencyKey: key,
+		Fingerprint:    fingerprint,
+		CreatedDate:    now,
+		ExpiresAt:      now.Add(s.ttl),
+	}, now.Add(-s.inFlightLimit))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if reserved {
+		return nil, nil
+	}
+
+	if record.Fingerprint != fingerprint {
+		return nil, models.ErrIdempotencyKeyReused
+	}
+
+	if record.Status != models.IdempotencyStatusCompleted {
+		return nil, models.ErrIdempotencyInProgress
+	}
+
+	return &record, nil
+}
+
+func (s *IdempotencyService) Complete(ctx context.Context, scope string, key string, status int, contentType string, body []byte) error {
+	return s.repo.CompleteIdempotencyKey(ctx, scope, key, status, contentType, body)
+}
+
+func (s *IdempotencyService) Release(ctx context.Context, scope string, key string) error {
+	return s.repo.ReleaseIdempotencyKey(ctx, scope, key)
+}
+
+func (s *IdempotencyService) RunCleaner(ctx context.Context, interval time.Duration) {
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.repo.DeleteExpiredIdempotencyKeys(ctx, time.Now().UTC()); err != nil {
+			logs.Error(err.Error(), logs.WithHandlerName("IdempotencyService_RunCleaner"))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
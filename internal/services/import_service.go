@@ -0,0 +1,474 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"favorite_service/internal/events"
+	"favorite_service/internal/models"
+	"favorite_service/metrics"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+type importListRepository interface {
+	GetFavoriteList(ctx context.Context, userId int) ([]models.FavoriteList, error)
+	CreateFavoriteList(ctx context.Context, favoriteList *models.FavoriteList) error
+}
+
+type importItemRepository interface {
+	GetFavoriteItemsByUserId(ctx context.Context, userId int) ([]models.FavoriteItem, error)
+	CreateFavoriteItem(ctx context.Context, favoriteItem models.FavoriteItem) (models.FavoriteItem, error)
+}
+
+type importUserClient interface {
+	VerifyUser(token string, ctx context.Context) (*models.Users, error)
+}
+
+type ImportService struct {
+	listRepo          importListRepository
+	itemRepo          importItemRepository
+	productClient     favoriteItemProductClient
+	userClient        importUserClient
+	countCache        favoriteCountInvalidator
+	transactor        transactor
+	outbox            eventOutbox
+	quotas            quotaEnforcer
+	audit             auditRecorder
+	verifyConcurrency int
+}
+
+func NewImportService(
+	listRepo importListRepository,
+	itemRepo importItemRepository,
+	productClient favoriteItemProductClient,
+	userClient importUserClient,
+	countCache favoriteCountInvalidator,
+	transactor transactor,
+	outbox eventOutbox,
+	quotas quotaEnforcer,
+	audit auditRecorder,
+	verifyConcurrency int) *ImportService {
+
+	return &ImportService{
+		listRepo:          listRepo,
+		itemRepo:          itemRepo,
+		productClient:     productClient,
+		userClient:        userClient,
+		countCache:        countCache,
+		transactor:        transactor,
+		outbox:            outbox,
+		quotas:            quotas,
+		audit:             audit,
+		verifyConcurrency: verifyConcurrency,
+	}
+}
+
+// importTarget satırların eklendiği liste. Id 0 ise liste henüz oluşturulmamıştır.
+type importTarget struct {
+	list  models.FavoriteList
+	items map[int]struct{}
+	isNew bool
+}
+
+// ImportFavorites satırları doğrular ve kullanıcının mevcut listeleriyle eşleştirir. Aynı isimli
+// (büyük/küçük harf duyarsız) bir liste varsa ürünler o listeye eklenir; listede zaten olan ya da
+// dosyada tekrar eden satırlar duplicate olarak raporlanır. dryRun true ise hiçbir şey yazılmaz.
+// Commit modunda geçersiz satır varsa hiçbir kayıt oluşturulmaz; aksi halde tüm listeler ve
+// ürünler tek bir transaction içinde oluşturulur.
+func (s *ImportService) ImportFavorites(rows []models.ImportRow, dryRun bool, token string, ctx context.Context) (models.ImportReport, error) {
+
+	user, err := s.userClient.VerifyUser(token, ctx)
+	if err != nil {
+		return models.ImportReport{}, err
+	}
+
+	return s.importRows(ctx, user.ID, rows, dryRun)
+}
+
+// ImportFromAccount sourceToken sahibi hesabın listelerini ve ürünlerini token sahibi hesaba aktarır.
+// Kaynak hesaptaki her liste ürünleriyle birlikte satırlara çevrilir ve dosyadan içe aktarımla aynı
+// kurallarla işlenir; liste metadata'sı ve varsayılan liste bilgisi taşınmaz. Kaynak hesap değiştirilmez.
+func (s *ImportService) ImportFromAccount(sourceToken string, dryRun bool, token string, ctx context.Context) (models.ImportReport, error) {
+
+	user, err := s.userClient.VerifyUser(token, ctx)
+	if err != nil {
+		return models.ImportReport{}, err
+	}
+
+	source, err := s.userClient.VerifyUser(sourceToken, ctx)
+	if err != nil {
+		return models.ImportReport{}, err
+	}
+
+	if source.ID == user.ID {
+		return models.ImportReport{}, models.ErrImportSameAccount
+	}
+
+	rows, err := s.accountImportRows(ctx, source.ID)
+	if err != nil {
+		return models.ImportReport{}, err
+	}
+
+	return s.importRows(ctx, user.ID, rows, dryRun)
+}
+
+// accountImportRows kaynak hesabın listelerini satırlara çevirir. Boş listeler ItemId 0 olan tek bir
+// satırla taşınır, böylece hedef hesapta da oluşturulur.
+func (s *ImportService) accountImportRows(ctx context.Context, sourceUserId int) ([]models.ImportRow, error) {
+
+	lists, err := s.listRepo.GetFavoriteList(ctx, sourceUserId)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.itemRepo.GetFavoriteItemsByUserId(ctx, sourceUserId)
+	if err != nil {
+		return nil, err
+	}
+
+	itemsByList := make(map[int][]models.FavoriteItem, len(lists))
+	for _, item := range items {
+		itemsByList[item.ListId] = append(itemsByList[item.ListId], item)
+	}
+
+	rows := make([]models.ImportRow, 0, len(items)+len(lists))
+
+	for _, list := range lists {
+
+		listItems := itemsByList[list.Id]
+
+		if len(listItems) == 0 {
+			rows = append(rows, models.ImportRow{Row: len(rows) + 1, ListName: list.ListName})
+			continue
+		}
+
+		for _, item := range listItems {
+			rows = append(rows, models.ImportRow{Row: len(rows) + 1, ListName: list.ListName, ItemId: item.ItemId})
+		}
+	}
+
+	if len(rows) == 0 {
+		return nil, models.ErrRecordNotFound
+	}
+
+	return rows, nil
+}
+
+func (s *ImportService) importRows(ctx context.Context, userId int, rows []models.ImportRow, dryRun bool) (models.ImportReport, error) {
+
+	if len(rows) > models.MaxImportRows {
+		return models.ImportReport{}, models.ErrImportTooManyRows
+	}
+
+	targets, err := s.loadImportTargets(ctx, userId)
+	if err != nil {
+		return models.ImportReport{}, err
+	}
+
+	productIds := make([]int, 0, len(rows))
+	seen := make(map[int]struct{}, len(rows))
+
+	for _, row := range rows {
+		if row.ItemId <= 0 || row.Validate() != nil {
+			continue
+		}
+
+		if _, ok := seen[row.ItemId]; !ok {
+			seen[row.ItemId] = struct{}{}
+			productIds = append(productIds, row.ItemId)
+		}
+	}
+
+	if len(productIds) > models.MaxImportProducts {
+		return models.ImportReport{}, models.ErrImportTooManyProducts
+	}
+
+	products, productErrs, err := verifyProducts(ctx, s.productClient, productIds, s.verifyConcurrency)
+	if err != nil {
+		return models.ImportReport{}, err
+	}
+
+	report := models.ImportReport{DryRun: dryRun, Rows: make([]models.ImportRowResult, 0, len(rows))}
+
+	for _, row := range rows {
+
+		result := models.ImportRowResult{ImportRow: row}
+
+		if err := row.Validate(); err != nil {
+			result.Status, result.Error = models.ImportRowStatusInvalid, err.Error()
+			report.Invalid++
+			report.Rows = append(report.Rows, result)
+			continue
+		}
+
+		if err, ok := productErrs[row.ItemId]; ok {
+			result.Status, result.Error = models.ImportRowStatusInvalid, fmt.Sprintf("ürün %d: %v", row.ItemId, err)
+			report.Invalid++
+			report.Rows = append(report.Rows, result)
+			continue
+		}
+
+		key := importListKey(row.ListName)
+		target, exists := targets[key]
+
+		if !exists {
+			target = &importTarget{
+				list:  models.FavoriteList{ListName: strings.TrimSpace(row.ListName), UserId: userId},
+				items: make(map[int]struct{}),
+				isNew: true,
+			}
+			targets[key] = target
+			report.ListsCreated++
+		}
+
+		result.NewList = target.isNew
+		result.Status = models.ImportRowStatusCreated
+
+		if row.ItemId == 0 {
+			if exists {
+				result.Status = models.ImportRowStatusDuplicate
+				report.Duplicates++
+			}
+		} else if _, ok := target.items[row.ItemId]; ok {
+			result.Status = models.ImportRowStatusDuplicate
+			report.Duplicates++
+		} else {
+			target.items[row.ItemId] = struct{}{}
+			report.ItemsCreated++
+		}
+
+		report.Rows = append(report.Rows, result)
+	}
+
+	if dryRun {
+		fillImportListIds(report.Rows, targets)
+		return report, nil
+	}
+
+	if report.Invalid > 0 {
+		return report, models.ErrImportHasInvalidRows
+	}
+
+	createdItemIds := make([]int, 0, report.ItemsCreated)
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+
+		for _, row := range report.Rows {
+
+			if row.Status != models.ImportRowStatusCreated {
+				continue
+			}
+
+			target := targets[importListKey(row.ListName)]
+
+			if target.list.Id == 0 {
+				if err := s.createImportedList(ctx, &target.list); err != nil {
+					return err
+				}
+			}
+
+			if row.ItemId == 0 {
+				continue
+			}
+
+			if err := s.createImportedItem(ctx, userId, target.list.Id, row.ItemId, products[row.ItemId]); err != nil {
+				return err
+			}
+
+			createdItemIds = append(createdItemIds, row.ItemId)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return models.ImportReport{}, err
+	}
+
+	fillImportListIds(report.Rows, targets)
+
+	report.Committed = true
+
+	if len(createdItemIds) > 0 {
+		s.countCache.Invalidate(createdItemIds...)
+	}
+
+	metrics.FavoriteListsCreated.Add(float64(report.ListsCreated))
+	metrics.FavoriteItemsAdded.Add(float64(report.ItemsCreated))
+
+	return report, nil
+}
+
+func (s *ImportService) loadImportTargets(ctx context.Context, userId int) (map[string]*importTarget, error) {
+
+	lists, err := s.listRepo.GetFavoriteList(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.itemRepo.GetFavoriteItemsByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make(map[string]*importTarget, len(lists))
+	byId := make(map[int]*importTarget, len(lists))
+
+	// Listeler id sırasıyla geldiği için aynı isimli birden fazla liste varsa en eskisi seçilir.
+	for _, list := range lists {
+
+		key := importListKey(list.ListName)
+
+		if _, ok := targets[key]; ok {
+			continue
+		}
+
+		target := &importTarget{list: list, items: make(map[int]struct{})}
+		targets[key] = target
+		byId[list.Id] = target
+	}
+
+	for _, item := range items {
+		if target, ok := byId[item.ListId]; ok {
+			target.items[item.ItemId] = struct{}{}
+		}
+	}
+
+	return targets, nil
+}
+
+func (s *ImportService) createImportedList(ctx context.Context, list *models.FavoriteList) error {
+
+	if err := s.quotas.CheckListQuota(ctx, list.UserId); err != nil {
+		return err
+	}
+
+	if err := s.listRepo.CreateFavoriteList(ctx, list); err != nil {
+		return err
+	}
+
+	if err := addAuditEntry(ctx, s.audit, listAuditEntry(list.UserId, models.AuditActionCreate, list.Id), nil, list); err != nil {
+		return err
+	}
+
+	return addOutboxEvent(ctx, s.outbox, events.TypeFavoriteListCreated, events.FavoriteListEventData{
+		UserId:   list.UserId,
+		ListId:   list.Id,
+		ListName: list.ListName,
+	})
+}
+
+func (s *ImportService) createImportedItem(ctx context.Context, userId int, listId int, itemId int, product models.Product) error {
+
+	if err := s.quotas.CheckItemQuota(ctx, listId); err != nil {
+		return err
+	}
+
+	created, err := s.itemRepo.CreateFavoriteItem(ctx, models.FavoriteItem{
+		ItemId:         itemId,
+		ListId:         listId,
+		Quantity:       1,
+		Priority:       models.PriorityNiceToHave,
+		FavoritedPrice: &product.Price,
+		FavoritedStock: &product.Stock,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := addAuditEntry(ctx, s.audit, itemAuditEntry(userId, models.AuditActionCreate, listId, created.ItemId), nil, created); err != nil {
+		return err
+	}
+
+	return addOutboxEvent(ctx, s.outbox, events.TypeFavoriteItemAdded, events.FavoriteItemEventData{
+		UserId: userId,
+		ListId: listId,
+		ItemId: created.ItemId,
+	})
+}
+
+func fillImportListIds(rows []models.ImportRowResult, targets map[string]*importTarget) {
+
+	for i := range rows {
+		if target, ok := targets[importListKey(rows[i].ListName)]; ok && rows[i].Status != models.ImportRowStatusInvalid {
+			rows[i].ListId = target.list.Id
+		}
+	}
+}
+
+// importListKey liste isimlerini Türkçe büyük/küçük harf kurallarıyla karşılaştırır (İ/i, I/ı).
+func importListKey(listName string) string {
+	return strings.ToLowerSpecial(unicode.TurkishCase, strings.TrimSpace(listName))
+}
+
+// verifyProducts her ürünü tekrar denemeden, kısa bir zaman aşımıyla bir kez sorgular. Bulunamayan
+// ürünler ayrı döner, böylece içe aktarım raporunda hangi satırın geçersiz olduğu gösterilebilir.
+// Product servisine ulaşılamazsa ürünler geçersiz sayılmaz: kalan istekler iptal edilir ve
+// ErrProductServiceUnavailable döner.
+func verifyProducts(ctx context.Context, productClient favoriteItemProductClient, productIds []int, concurrency int) (map[int]models.Product, map[int]error, error) {
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	lookupCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	products := make(map[int]models.Product, len(productIds))
+	productErrs := make(map[int]error)
+
+	var upstreamErr error
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	sm := make(chan struct{}, concurrency)
+
+	for _, productId := range productIds {
+
+		select {
+		case <-lookupCtx.Done():
+		case sm <- struct{}{}:
+			wg.Add(1)
+			go func(productId int) {
+				defer wg.Done()
+				defer func() { <-sm }()
+
+				productCtx, cancelProduct := context.WithTimeout(lookupCtx, productSnapshotTimeout)
+				product, err := productClient.GetProduct(productCtx, productId)
+				cancelProduct()
+
+				mu.Lock()
+				defer mu.Unlock()
+
+				switch {
+				case err == nil:
+					products[productId] = *product
+				case errors.Is(err, models.ErrRecordNotFound):
+					productErrs[productId] = models.ErrProductNotFound
+				case upstreamErr == nil:
+					upstreamErr = err
+					cancel()
+				}
+			}(productId)
+		}
+	}
+
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	if upstreamErr != nil {
+
+		if errors.Is(upstreamErr, models.ErrProductServiceUnavailable) {
+			return nil, nil, upstreamErr
+		}
+
+		return nil, nil, fmt.Errorf("%w: %v", models.ErrProductServiceUnavailable, upstreamErr)
+	}
+
+	return products, productErrs, nil
+}
@@ -0,0 +1,24 @@
+package services
+
+import (
+	"context"
+	"favorite_service/internal/events"
+)
+
+type transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type eventOutbox interface {
+	AddEvent(ctx context.Context, event events.Event) error
+}
+
+func addOutboxEvent(ctx context.Context, outbox eventOutbox, eventType string, data interface{}) error {
+
+	event, err := events.NewEvent(eventType, data)
+	if err != nil {
+		return err
+	}
+
+	return outbox.AddEvent(ctx, event)
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"favorite_service/internal/models"
+	"favorite_service/logs"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type popularityRepository interface {
+	GetPopularProducts(ctx context.Context, since time.Time, limit int) ([]models.ProductFavoriteCount, error)
+	RefreshPopularitySummary(ctx context.Context) error
+}
+
+type popularityProductClient interface {
+	VerifyProduct(ctx context.Context, productId int) (*models.Product, error)
+}
+
+type PopularityService struct {
+	repo          popularityRepository
+	productClient popularityProductClient
+	concurrency   int
+
+	mu    sync.RWMutex
+	cache map[string][]models.PopularProduct
+}
+
+func NewPopularityService(repo popularityRepository, productClient popularityProductClient, concurrency int) *PopularityService {
+	return &PopularityService{
+		repo:          repo,
+		productClient: productClient,
+		concurrency:   concurrency,
+		cache:         make(map[string][]models.PopularProduct),
+	}
+}
+
+// GetPopularProducts sonuçları özet tablo bir sonraki yenilenene kadar önbellekte tutar,
+// böylece her istek product servisine tekrar gitmez. Özet tablo gün bazında olduğu için
+// window tam güne yuvarlanır. Ürün bilgisi alınamayan sonuçlar önbelleğe yazılmaz.
+func (s *PopularityService) GetPopularProducts(ctx context.Context, window time.Duration, limit int) ([]models.PopularProduct, error) {
+
+	days := int(window / (24 * time.Hour))
+	if days < 1 {
+		days = 1
+	}
+
+	window = time.Duration(days) * 24 * time.Hour
+
+	key := fmt.Sprintf("%dd:%d", days, limit)
+
+	s.mu.RLock()
+	cached, ok := s.cache[key]
+	s.mu.RUnlock()
+
+	if ok {
+		return cached, nil
+	}
+
+	counts, err := s.repo.GetPopularProducts(ctx, time.Now().Add(-window), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	products := make([]*models.Product, len(counts))
+	sm := make(chan struct{}, s.concurrency)
+	var wg sync.WaitGroup
+	var failed atomic.Bool
+
+	for i, count := range counts {
+		wg.Add(1)
+		sm <- struct{}{}
+		go func(i int, productId int) {
+			defer wg.Done()
+			defer func() { <-sm }()
+
+			product, err := s.productClient.VerifyProduct(ctx, productId)
+			if err != nil {
+				logs.Warning(fmt.Sprintf("Ürün %d bilgisi alınamadı: %v", productId, err),
+					logs.WithHandlerName("PopularityService_GetPopularProducts"),
+				)
+				failed.Store(true)
+				return
+			}
+			products[i] = product
+		}(i, count.ItemId)
+	}
+
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	popularProducts := make([]models.PopularProduct, 0, len(counts))
+	for i, count := range counts {
+		if products[i] == nil {
+			continue
+		}
+		popularProducts = append(popularProducts, models.PopularProduct{
+			Product:       *products[i],
+			FavoriteCount: count.FavoriteCount,
+		})
+	}
+
+	if failed.Load() {
+		return popularProducts, nil
+	}
+
+	s.mu.Lock()
+	s.cache[key] = popularProducts
+	s.mu.Unlock()
+
+	return popularProducts, nil
+}
+
+func (s *PopularityService) RefreshSummary(ctx context.Context) error {
+
+	if err := s.repo.RefreshPopularitySummary(ctx); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.cache = make(map[string][]models.PopularProduct)
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *PopularityService) RunSummaryRefresher(ctx context.Context, interval time.Duration) {
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.RefreshSummary(ctx); err != nil {
+			logs.Error(err.Error(), logs.WithHandlerName("PopularityService_RefreshSummary"))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"favorite_service/internal/events"
+	"favorite_service/internal/models"
+	"time"
+)
+
+type privacyRepository interface {
+	EraseUserData(ctx context.Context, userId int) (models.ErasureResult, error)
+}
+
+type privacyListRepository interface {
+	GetFavoriteList(ctx context.Context, userId int) ([]models.FavoriteList, error)
+}
+
+type privacyItemRepository interface {
+	GetFavoriteItemsByUserId(ctx context.Context, userId int) ([]models.FavoriteItem, error)
+}
+
+type privacyUserClient interface {
+	VerifyUser(token string, ctx context.Context) (*models.Users, error)
+}
+
+type PrivacyService struct {
+	repo       privacyRepository
+	listRepo   privacyListRepository
+	itemRepo   privacyItemRepository
+	userClient privacyUserClient
+	countCache favoriteListCountInvalidator
+	transactor transactor
+	outbox     eventOutbox
+}
+
+func NewPrivacyService(
+	repo privacyRepository,
+	listRepo privacyListRepository,
+	itemRepo privacyItemRepository,
+	userClient privacyUserClient,
+	countCache favoriteListCountInvalidator,
+	transactor transactor,
+	outbox eventOutbox) *PrivacyService {
+
+	return &PrivacyService{
+		repo:       repo,
+		listRepo:   listRepo,
+		itemRepo:   itemRepo,
+		userClient: userClient,
+		countCache: countCache,
+		transactor: transactor,
+		outbox:     outbox,
+	}
+}
+
+// ExportUserData kullanıcının tüm listelerini ürünleriyle birlikte döner. Ürün bilgileri
+// product servisinden zenginleştirilmez, yalnızca bu serviste tutulan veriler dışa aktarılır.
+func (s *PrivacyService) ExportUserData(token string, ctx context.Context) (models.UserDataExport, error) {
+
+	user, err := s.userClient.VerifyUser(token, ctx)
+	if err != nil {
+		return models.UserDataExport{}, err
+	}
+
+	lists, err := s.listRepo.GetFavoriteList(ctx, user.ID)
+	if err != nil {
+		return models.UserDataExport{}, err
+	}
+
+	items, err := s.itemRepo.GetFavoriteItemsByUserId(ctx, user.ID)
+	if err != nil {
+		return models.UserDataExport{}, err
+	}
+
+	itemsByList := make(map[int][]models.FavoriteItem, len(lists))
+	for _, item := range items {
+		itemsByList[item.ListId] = append(itemsByList[item.ListId], item)
+	}
+
+	export := models.UserDataExport{
+		UserId:     user.ID,
+		ExportedAt: time.Now().UTC(),
+		Lists:      make([]models.ExportedList, 0, len(lists)),
+	}
+
+	for _, list := range lists {
+
+		listItems := itemsByList[list.Id]
+		if listItems == nil {
+			listItems = []models.FavoriteItem{}
+		}
+
+		export.Lists = append(export.Lists, models.ExportedList{FavoriteList: list, Items: listItems})
+	}
+
+	return export, nil
+}
+
+// EraseMe token sahibi kullanıcının tüm verilerini siler.
+func (s *PrivacyService) EraseMe(token string, ctx context.Context) (models.ErasureResult, error) {
+
+	user, err := s.userClient.VerifyUser(token, ctx)
+	if err != nil {
+		return models.ErasureResult{}, err
+	}
+
+	return s.EraseUser(ctx, user.ID)
+}
+
+// EraseUser hesap silindiğinde user servisi tarafından da çağrılır; tekrar çağrılması güvenlidir.
+// Kullanıcının eski eventleri silindikten sonra, aynı transaction içinde silinen her ürün ve liste için
+// silme eventi yazılır; böylece tüketiciler kendi kopyalarını temizleyebilir.
+func (s *PrivacyService) EraseUser(ctx context.Context, userId int) (models.ErasureResult, error) {
+
+	var result models.ErasureResult
+
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+
+		var err error
+
+		result, err = s.repo.EraseUserData(ctx, userId)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range result.Items {
+			if err := addOutboxEvent(ctx, s.outbox, events.TypeFavoriteItemRemoved, events.FavoriteItemEventData{
+				UserId: userId,
+				ListId: item.ListId,
+				ItemId: item.ItemId,
+			}); err != nil {
+				return err
+			}
+		}
+
+		for _, list := range result.Lists {
+			if err := addOutboxEvent(ctx, s.outbox, events.TypeFavoriteListDeleted, events.FavoriteListEventData{
+				UserId: userId,
+				ListId: list.Id,
+			}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return models.ErasureResult{}, err
+	}
+
+	if result.ItemsDeleted > 0 {
+		s.countCache.InvalidateAll()
+	}
+
+	return result, nil
+}
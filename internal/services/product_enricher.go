@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"favorite_service/internal/models"
+	"sync"
+)
+
+// enrichProducts verilen ürün id'lerini en fazla concurrency kadar eşzamanlı istekle
+// product servisinden çeker. Herhangi bir istek hata verirse kalan istekler iptal edilir.
+func enrichProducts(ctx context.Context, productClient favoriteListProductClient, productIds []int, concurrency int) (map[int]models.Product, error) {
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	products := make([]*models.Product, len(productIds))
+	sm := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+
+	for i, productId := range productIds {
+
+		select {
+		case <-ctx.Done():
+		case sm <- struct{}{}:
+			wg.Add(1)
+			go func(i int, productId int) {
+				defer wg.Done()
+				defer func() { <-sm }()
+
+				product, err := productClient.VerifyProduct(ctx, productId)
+				if err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					return
+				}
+				products[i] = product
+			}(i, productId)
+		}
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	enriched := make(map[int]models.Product, len(productIds))
+	for i, productId := range productIds {
+		enriched[productId] = *products[i]
+	}
+
+	return enriched, nil
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"favorite_service/internal/models"
+	"favorite_service/logs"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+type productWatchRepository interface {
+	GetFavoritedItemIds(ctx context.Context, afterItemId int, limit int) ([]int, error)
+	GetProductWatches(ctx context.Context, itemIds []int) ([]models.ProductWatch, error)
+	SaveProductWatch(ctx context.Context, previous *models.ProductWatch, product models.Product) error
+	GetUserAlerts(ctx context.Context, userId int, limit int) ([]models.ProductAlert, error)
+}
+
+type productWatchProductClient interface {
+	GetProduct(ctx context.Context, productId int) (*models.Product, error)
+}
+
+type productWatchUserClient interface {
+	VerifyUser(token string, ctx context.Context) (*models.Users, error)
+}
+
+type ProductWatchService struct {
+	repo          productWatchRepository
+	productClient productWatchProductClient
+	userClient    productWatchUserClient
+	batchSize     int
+	concurrency   int
+}
+
+func NewProductWatchService(repo productWatchRepository, productClient productWatchProductClient,
+	userClient productWatchUserClient, batchSize int, concurrency int) *ProductWatchService {
+	return &ProductWatchService{
+		repo:          repo,
+		productClient: productClient,
+		userClient:    userClient,
+		batchSize:     batchSize,
+		concurrency:   concurrency,
+	}
+}
+
+func (s *ProductWatchService) GetUserAlerts(token string, ctx context.Context, limit int) ([]models.ProductAlert, error) {
+
+	user, err := s.userClient.VerifyUser(token, ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	alerts, err := s.repo.GetUserAlerts(ctx, user.ID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range alerts {
+		alert := &alerts[i]
+		if alert.AlertType != models.AlertTypePriceDrop || alert.OldPrice == nil || alert.NewPrice == nil || *alert.OldPrice <= 0 {
+			continue
+		}
+
+		percent := math.Round((*alert.OldPrice-*alert.NewPrice) / *alert.OldPrice * 10000) / 100
+		alert.PriceDropPercent = &percent
+	}
+
+	return alerts, nil
+}
+
+func (s *ProductWatchService) RunPoller(ctx context.Context, interval time.Duration) {
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.CheckFavoritedProducts(ctx); err != nil {
+			logs.Error(err.Error(), logs.WithHandlerName("ProductWatchService_CheckFavoritedProducts"))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckFavoritedProducts favorilenmiş tüm ürünleri batchSize'lık gruplar halinde product
+// servisinden tekrar okur ve son bilinen duruma göre değişiklikleri kaydeder.
+func (s *ProductWatchService) CheckFavoritedProducts(ctx context.Context) error {
+
+	afterItemId := 0
+
+	for {
+		itemIds, err := s.repo.GetFavoritedItemIds(ctx, afterItemId, s.batchSize)
+		if err != nil {
+			return err
+		}
+
+		if len(itemIds) == 0 {
+			return nil
+		}
+
+		if err := s.checkBatch(ctx, itemIds); err != nil {
+			return err
+		}
+
+		afterItemId = itemIds[len(itemIds)-1]
+	}
+}
+
+func (s *ProductWatchService) checkBatch(ctx context.Context, itemIds []int) error {
+
+	watches, err := s.repo.GetProductWatches(ctx, itemIds)
+	if err != nil {
+		return err
+	}
+
+	previous := make(map[int]models.ProductWatch, len(watches))
+	for _, watch := range watches {
+		previous[watch.ItemId] = watch
+	}
+
+	var wg sync.WaitGroup
+	sm := make(chan struct{}, s.concurrency)
+
+	for _, itemId := range itemIds {
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		sm <- struct{}{}
+		go func(itemId int) {
+			defer wg.Done()
+			defer func() { <-sm }()
+
+			product, err := s.productClient.GetProduct(ctx, itemId)
+			if err != nil {
+				if !errors.Is(err, models.ErrRecordNotFound) {
+					logs.Warning(fmt.Sprintf("Ürün %d kontrol edilemedi: %v", itemId, err),
+						logs.WithHandlerName("ProductWatchService_CheckFavoritedProducts"),
+					)
+				}
+				return
+			}
+
+			product.ID = itemId
+
+			var last *models.ProductWatch
+			if watch, ok := previous[itemId]; ok {
+				last = &watch
+			}
+
+			if err := s.repo.SaveProductWatch(ctx, last, *product); err != nil {
+				logs.Error(err.Error(), logs.WithHandlerName("ProductWatchService_CheckFavoritedProducts"))
+			}
+		}(itemId)
+	}
+
+	wg.Wait()
+
+	return ctx.Err()
+}
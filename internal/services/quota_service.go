@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"favorite_service/internal/models"
+)
+
+type quotaRepository interface {
+	LockAndCountUserLists(ctx context.Context, userId int) (int, error)
+	LockAndCountListItems(ctx context.Context, listId int) (int, error)
+	CountUserLists(ctx context.Context, userId int) (int, error)
+	GetListItemCounts(ctx context.Context, userId int) ([]models.ListQuotaUsage, error)
+}
+
+type quotaUserClient interface {
+	VerifyUser(token string, ctx context.Context) (*models.Users, error)
+}
+
+// quotaEnforcer liste ve ürün ekleme işlemlerinden önce transaction içinde çağrılır.
+type quotaEnforcer interface {
+	CheckListQuota(ctx context.Context, userId int) error
+	CheckItemQuota(ctx context.Context, listId int) error
+}
+
+type QuotaService struct {
+	repo       quotaRepository
+	userClient quotaUserClient
+	limits     models.QuotaLimits
+}
+
+func NewQuotaService(repo quotaRepository, userClient quotaUserClient, limits models.QuotaLimits) *QuotaService {
+	return &QuotaService{
+		repo:       repo,
+		userClient: userClient,
+		limits:     limits,
+	}
+}
+
+// CheckListQuota kullanıcının yeni bir liste oluşturup oluşturamayacağını kontrol eder.
+// Kilit transaction sonuna kadar tutulduğu için eşzamanlı istekler sınırı aşamaz.
+func (s *QuotaService) CheckListQuota(ctx context.Context, userId int) error {
+
+	if s.limits.MaxListsPerUser <= 0 {
+		return nil
+	}
+
+	used, err := s.repo.LockAndCountUserLists(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	if used >= s.limits.MaxListsPerUser {
+		return &models.QuotaExceededError{Resource: models.QuotaResourceLists, Used: used, Limit: s.limits.MaxListsPerUser}
+	}
+
+	return nil
+}
+
+// CheckItemQuota listeye yeni bir ürün eklenip eklenemeyeceğini kontrol eder.
+func (s *QuotaService) CheckItemQuota(ctx context.Context, listId int) error {
+
+	if s.limits.MaxItemsPerList <= 0 {
+		return nil
+	}
+
+	used, err := s.repo.LockAndCountListItems(ctx, listId)
+	if err != nil {
+		return err
+	}
+
+	if used >= s.limits.MaxItemsPerList {
+		return &models.QuotaExceededError{Resource: models.QuotaResourceListItems, Used: used, Limit: s.limits.MaxItemsPerList}
+	}
+
+	return nil
+}
+
+func (s *QuotaService) GetUserQuotaUsage(token string, ctx context.Context) (models.UserQuotaUsage, error) {
+
+	user, err := s.userClient.VerifyUser(token, ctx)
+
+	if err != nil {
+		return models.UserQuotaUsage{}, err
+	}
+
+	lists, err := s.repo.CountUserLists(ctx, user.ID)
+	if err != nil {
+		return models.UserQuotaUsage{}, err
+	}
+
+	itemsPerList, err := s.repo.GetListItemCounts(ctx, user.ID)
+	if err != nil {
+		return models.UserQuotaUsage{}, err
+	}
+
+	for i := range itemsPerList {
+		itemsPerList[i].Limit = s.limits.MaxItemsPerList
+	}
+
+	if itemsPerList == nil {
+		itemsPerList = []models.ListQuotaUsage{}
+	}
+
+	return models.UserQuotaUsage{
+		Lists:        models.QuotaUsage{Used: lists, Limit: s.limits.MaxListsPerUser},
+		ItemsPerList: itemsPerList,
+	}, nil
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"favorite_service/internal/models"
+	"strings"
+)
+
+type webhookRepository interface {
+	CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error
+	GetSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error)
+	GetSubscription(ctx context.Context, subscriptionId int) (models.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, subscriptionId int) error
+	GetDeliveries(ctx context.Context, subscriptionId int, limit int) ([]models.WebhookDelivery, error)
+}
+
+type WebhookService struct {
+	repo webhookRepository
+}
+
+func NewWebhookService(repo webhookRepository) *WebhookService {
+	return &WebhookService{
+		repo: repo,
+	}
+}
+
+// CreateSubscription secret verilmezse rastgele üretir. Secret yalnızca bu yanıtta döner.
+func (s *WebhookService) CreateSubscription(ctx context.Context, request models.CreateWebhookSubscription) (models.WebhookSubscriptionResponse, error) {
+
+	secret := request.Secret
+	if secret == "" {
+		buf := make([]byte, 32)
+		if _, err := rand.Read(buf); err != nil {
+			return models.WebhookSubscriptionResponse{}, err
+		}
+		secret = hex.EncodeToString(buf)
+	}
+
+	subscription := models.WebhookSubscription{
+		Url:        request.Url,
+		EventTypes: strings.Join(request.EventTypes, ","),
+		Secret:     secret,
+		Active:     true,
+	}
+
+	if err := s.repo.CreateSubscription(ctx, &subscription); err != nil {
+		return models.WebhookSubscriptionResponse{}, err
+	}
+
+	return models.WebhookSubscriptionResponse{
+		WebhookSubscription: subscription,
+		Secret:              secret,
+	}, nil
+}
+
+func (s *WebhookService) GetSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	return s.repo.GetSubscriptions(ctx)
+}
+
+func (s *WebhookService) DeleteSubscription(ctx context.Context, subscriptionId int) error {
+	return s.repo.DeleteSubscription(ctx, subscriptionId)
+}
+
+func (s *WebhookService) GetDeliveries(ctx context.Context, subscriptionId int, limit int) ([]models.WebhookDelivery, error) {
+
+	if _, err := s.repo.GetSubscription(ctx, subscriptionId); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetDeliveries(ctx, subscriptionId, limit)
+}
@@ -0,0 +1,55 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"favorite_service/internal/events"
+	"favorite_service/internal/models"
+)
+
+type subscriptionStore interface {
+	GetActiveSubscriptionsForEvent(ctx context.Context, eventType string) ([]models.WebhookSubscription, error)
+	CreateDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error
+}
+
+// Dispatcher outbox relay'inden gelen eventleri ilgili aboneliklere teslimat kaydı
+// olarak yazar; asıl HTTP gönderimini DeliveryWorker yapar.
+type Dispatcher struct {
+	store subscriptionStore
+}
+
+func NewDispatcher(store subscriptionStore) *Dispatcher {
+	return &Dispatcher{
+		store: store,
+	}
+}
+
+func (d *Dispatcher) Publish(ctx context.Context, event events.Event) error {
+
+	subscriptions, err := d.store.GetActiveSubscriptionsForEvent(ctx, event.Type)
+	if err != nil {
+		return err
+	}
+
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	deliveries := make([]models.WebhookDelivery, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		deliveries = append(deliveries, models.WebhookDelivery{
+			SubscriptionId: subscription.Id,
+			EventId:        event.ID,
+			EventType:      event.Type,
+			Payload:        string(payload),
+			Status:         models.WebhookDeliveryPending,
+		})
+	}
+
+	return d.store.CreateDeliveries(ctx, deliveries)
+}
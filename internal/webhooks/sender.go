@@ -0,0 +1,73 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	DeliveryHeader  = "X-Webhook-Delivery"
+	EventTypeHeader = "X-Webhook-Event"
+)
+
+// Sign alıcının doğrulayacağı imzayı "t=<unix>,v1=<hex>" biçiminde üretir. İmza
+// HMAC-SHA256(secret, "<unix>.<body>") olup zaman damgası replay saldırılarına karşı imzaya dahildir.
+func Sign(secret string, timestamp int64, body []byte) string {
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, hex.EncodeToString(mac.Sum(nil)))
+}
+
+type Sender struct {
+	client *http.Client
+}
+
+func NewSender(timeout time.Duration) *Sender {
+	return &Sender{
+		client: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+// Send gövdeyi imzalayarak gönderir ve alınan status kodunu döner. 2xx dışındaki
+// yanıtlar hata olarak kabul edilir.
+func (s *Sender) Send(ctx context.Context, url string, secret string, deliveryId int, eventType string, body []byte) (int, error) {
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(secret, time.Now().Unix(), body))
+	req.Header.Set(DeliveryHeader, strconv.Itoa(deliveryId))
+	req.Header.Set(EventTypeHeader, eventType)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook %d status döndü", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
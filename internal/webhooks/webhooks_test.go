@@ -0,0 +1,145 @@
+package webhooks
+
+import (
+	"context"
+	"favorite_service/internal/models"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeDeliveryStore struct {
+	subscription models.WebhookSubscription
+	deliveries   map[int]models.WebhookDelivery
+}
+
+func (s *fakeDeliveryStore) ClaimDueDeliveries(ctx context.Context, limit int, leaseUntil time.Time) ([]models.WebhookDelivery, error) {
+	var due []models.WebhookDelivery
+	for _, delivery := range s.deliveries {
+		if delivery.Status == models.WebhookDeliveryPending {
+			due = append(due, delivery)
+		}
+	}
+	return due, nil
+}
+
+func (s *fakeDeliveryStore) UpdateDelivery(ctx context.Context, delivery models.WebhookDelivery) error {
+	s.deliveries[delivery.Id] = delivery
+	return nil
+}
+
+func (s *fakeDeliveryStore) GetSubscription(ctx context.Context, subscriptionId int) (models.WebhookSubscription, error) {
+	if subscriptionId != s.subscription.Id {
+		return models.WebhookSubscription{}, models.ErrRecordNotFound
+	}
+	return s.subscription, nil
+}
+
+type receiver struct {
+	mu         sync.Mutex
+	statusCode int
+	signatures []string
+	bodies     []string
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.signatures = append(r.signatures, req.Header.Get(SignatureHeader))
+	r.bodies = append(r.bodies, string(body))
+	w.WriteHeader(r.statusCode)
+}
+
+func verify(secret string, signature string, body []byte) bool {
+	parts := strings.Split(signature, ",")
+	timestamp, err := strconv.ParseInt(strings.TrimPrefix(parts[0], "t="), 10, 64)
+	if err != nil {
+		return false
+	}
+	return Sign(secret, timestamp, body) == signature
+}
+
+func TestWebhooks(t *testing.T) {
+
+	rcv := &receiver{statusCode: http.StatusOK}
+	server := httptest.NewServer(rcv)
+	defer server.Close()
+
+	const secret = "super-secret-value-123"
+
+	store := &fakeDeliveryStore{
+		subscription: models.WebhookSubscription{Id: 1, Url: server.URL, Secret: secret, Active: true},
+		deliveries: map[int]models.WebhookDelivery{
+			1: {Id: 1, SubscriptionId: 1, EventType: "favorite.item.added", Payload: `{"id":"a"}`, Status: models.WebhookDeliveryPending},
+		},
+	}
+
+	worker := NewDeliveryWorker(store, NewSender(time.Second), 10, 2, time.Minute, time.Hour)
+
+	t.Run("TestSignedDelivery", func(t *testing.T) {
+
+		processed, err := worker.DeliverOnce(context.Background())
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, 1, processed)
+
+		assert.Equal(t, models.WebhookDeliverySucceeded, store.deliveries[1].Status)
+
+		assert.Len(t, rcv.signatures, 1)
+
+		assert.True(t, verify(secret, rcv.signatures[0], []byte(rcv.bodies[0])))
+
+		assert.False(t, verify("wrong-secret", rcv.signatures[0], []byte(rcv.bodies[0])))
+
+	})
+
+	t.Run("TestRetryThenDeadLetter", func(t *testing.T) {
+
+		rcv.statusCode = http.StatusInternalServerError
+
+		store.deliveries[2] = models.WebhookDelivery{Id: 2, SubscriptionId: 1, EventType: "favorite.item.removed", Payload: `{"id":"b"}`, Status: models.WebhookDeliveryPending}
+
+		_, err := worker.DeliverOnce(context.Background())
+
+		assert.Nil(t, err)
+
+		delivery := store.deliveries[2]
+
+		assert.Equal(t, models.WebhookDeliveryPending, delivery.Status)
+
+		assert.Equal(t, 1, delivery.Attempts)
+
+		assert.Equal(t, http.StatusInternalServerError, *delivery.LastStatusCode)
+
+		assert.True(t, delivery.NextAttemptDate.After(time.Now()))
+
+		_, err = worker.DeliverOnce(context.Background())
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, models.WebhookDeliveryDead, store.deliveries[2].Status)
+
+	})
+
+	t.Run("TestBackoff", func(t *testing.T) {
+
+		assert.Equal(t, time.Minute, worker.Backoff(1))
+
+		assert.Equal(t, 4*time.Minute, worker.Backoff(3))
+
+		assert.Equal(t, time.Hour, worker.Backoff(20))
+
+	})
+
+}
@@ -0,0 +1,153 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"favorite_service/internal/models"
+	"favorite_service/logs"
+	"fmt"
+	"time"
+)
+
+type deliveryStore interface {
+	ClaimDueDeliveries(ctx context.Context, limit int, leaseUntil time.Time) ([]models.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery models.WebhookDelivery) error
+	GetSubscription(ctx context.Context, subscriptionId int) (models.WebhookSubscription, error)
+}
+
+type sender interface {
+	Send(ctx context.Context, url string, secret string, deliveryId int, eventType string, body []byte) (int, error)
+}
+
+// claimLease sahiplenilen teslimatların diğer worker'lardan gizlendiği süredir; bir batch'in
+// gönderim süresinden (batchSize * sender timeout) uzun olmalıdır.
+const claimLease = 5 * time.Minute
+
+type DeliveryWorker struct {
+	store       deliveryStore
+	sender      sender
+	batchSize   int
+	maxAttempts int
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+}
+
+func NewDeliveryWorker(store deliveryStore, sender sender,
+	batchSize int, maxAttempts int, baseBackoff time.Duration, maxBackoff time.Duration) *DeliveryWorker {
+	return &DeliveryWorker{
+		store:       store,
+		sender:      sender,
+		batchSize:   batchSize,
+		maxAttempts: maxAttempts,
+		baseBackoff: baseBackoff,
+		maxBackoff:  maxBackoff,
+	}
+}
+
+func (w *DeliveryWorker) Run(ctx context.Context, interval time.Duration) {
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.DeliverOnce(ctx); err != nil {
+			logs.Error(err.Error(), logs.WithHandlerName("WebhookDeliveryWorker"))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverOnce zamanı gelen teslimatları sahiplenir, transaction dışında gönderir ve
+// işlenen teslimat sayısını döner. Yavaş bir abone satır kilidi ya da bağlantı tutmaz.
+func (w *DeliveryWorker) DeliverOnce(ctx context.Context) (int, error) {
+
+	deliveries, err := w.store.ClaimDueDeliveries(ctx, w.batchSize, time.Now().Add(claimLease))
+	if err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	subscriptions := make(map[int]*models.WebhookSubscription)
+
+	for _, delivery := range deliveries {
+
+		subscription, ok := subscriptions[delivery.SubscriptionId]
+		if !ok {
+			found, err := w.store.GetSubscription(ctx, delivery.SubscriptionId)
+			if err != nil && !errors.Is(err, models.ErrRecordNotFound) {
+				return processed, err
+			}
+			if err == nil {
+				subscription = &found
+			}
+			subscriptions[delivery.SubscriptionId] = subscription
+		}
+
+		w.attempt(ctx, &delivery, subscription)
+
+		if err := w.store.UpdateDelivery(ctx, delivery); err != nil {
+			return processed, err
+		}
+
+		processed++
+	}
+
+	return processed, nil
+}
+
+func (w *DeliveryWorker) attempt(ctx context.Context, delivery *models.WebhookDelivery, subscription *models.WebhookSubscription) {
+
+	delivery.Attempts++
+
+	if subscription == nil || !subscription.Active {
+		message := "abonelik bulunamadı veya pasif"
+		delivery.Status = models.WebhookDeliveryDead
+		delivery.LastError = &message
+		return
+	}
+
+	statusCode, err := w.sender.Send(ctx, subscription.Url, subscription.Secret, delivery.Id, delivery.EventType, []byte(delivery.Payload))
+
+	if statusCode != 0 {
+		delivery.LastStatusCode = &statusCode
+	}
+
+	if err == nil {
+		delivery.Status = models.WebhookDeliverySucceeded
+		delivery.LastError = nil
+		return
+	}
+
+	message := err.Error()
+	delivery.LastError = &message
+
+	if delivery.Attempts >= w.maxAttempts {
+		delivery.Status = models.WebhookDeliveryDead
+
+		logs.Warning(fmt.Sprintf("Webhook teslimatı %d dead-letter durumuna alındı: %s", delivery.Id, message),
+			logs.WithHandlerName("WebhookDeliveryWorker"),
+		)
+		return
+	}
+
+	delivery.NextAttemptDate = time.Now().Add(w.Backoff(delivery.Attempts))
+}
+
+// Backoff n. denemeden sonra beklenecek süreyi üstel olarak hesaplar ve maxBackoff ile sınırlar.
+func (w *DeliveryWorker) Backoff(attempts int) time.Duration {
+
+	backoff := w.baseBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= w.maxBackoff {
+			return w.maxBackoff
+		}
+	}
+
+	return backoff
+}
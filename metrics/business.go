@@ -0,0 +1,38 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	FavoriteItemsAdded = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "favorite_service",
+		Name:      "favorite_items_added_total",
+		Help:      "Listelere eklenen favori ürün sayısı.",
+	})
+
+	FavoriteItemsRemoved = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "favorite_service",
+		Name:      "favorite_items_removed_total",
+		Help:      "Listelerden çıkarılan favori ürün sayısı.",
+	})
+
+	FavoriteListsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "favorite_service",
+		Name:      "favorite_lists_created_total",
+		Help:      "Oluşturulan favori liste sayısı.",
+	})
+
+	FavoriteListsDeleted = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "favorite_service",
+		Name:      "favorite_lists_deleted_total",
+		Help:      "Silinen favori liste sayısı.",
+	})
+)
+
+func BusinessCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		FavoriteItemsAdded,
+		FavoriteItemsRemoved,
+		FavoriteListsCreated,
+		FavoriteListsDeleted,
+	}
+}
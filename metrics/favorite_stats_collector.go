@@ -0,0 +1,120 @@
+package metrics
+
+import (
+	"context"
+	"favorite_service/internal/models"
+	"favorite_service/logs"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type favoriteStatsRepository interface {
+	CountFavoriteLists(ctx context.Context) (int64, error)
+	CountFavoriteItems(ctx context.Context) (int64, error)
+	GetTopFavoritedProducts(ctx context.Context, limit int) ([]models.ProductFavoriteCount, error)
+}
+
+// FavoriteStatsCollector toplam liste/ürün sayılarını ve en çok favorilenen ürünleri
+// belirli aralıklarla Postgres'ten okuyup gauge olarak yayınlar.
+type FavoriteStatsCollector struct {
+	repo        favoriteStatsRepository
+	interval    time.Duration
+	topN        int
+	totalLists  prometheus.Gauge
+	totalItems  prometheus.Gauge
+	topProducts *prometheus.GaugeVec
+
+	// mu Reset ile yeni değerlerin yazılması arasında yapılan scrape'lerin
+	// boş top ürün serisi görmesini engeller.
+	mu sync.Mutex
+}
+
+func NewFavoriteStatsCollector(repo favoriteStatsRepository, interval time.Duration, topN int) *FavoriteStatsCollector {
+	return &FavoriteStatsCollector{
+		repo:     repo,
+		interval: interval,
+		topN:     topN,
+		totalLists: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "favorite_service",
+			Name:      "favorite_lists",
+			Help:      "Veritabanındaki toplam favori liste sayısı.",
+		}),
+		totalItems: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "favorite_service",
+			Name:      "favorite_items",
+			Help:      "Veritabanındaki toplam favori ürün sayısı.",
+		}),
+		topProducts: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "favorite_service",
+			Name:      "top_favorited_product_favorites",
+			Help:      "En çok favorilenen ilk N ürünün favori sayısı.",
+		}, []string{"product_id"}),
+	}
+}
+
+func (c *FavoriteStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.totalLists.Describe(ch)
+	c.totalItems.Describe(ch)
+	c.topProducts.Describe(ch)
+}
+
+func (c *FavoriteStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.totalLists.Collect(ch)
+	c.totalItems.Collect(ch)
+	c.topProducts.Collect(ch)
+}
+
+func (c *FavoriteStatsCollector) Run(ctx context.Context) {
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.refresh(ctx); err != nil {
+			logs.Error(err.Error(), logs.WithHandlerName("FavoriteStatsCollector"))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *FavoriteStatsCollector) refresh(ctx context.Context) error {
+
+	lists, err := c.repo.CountFavoriteLists(ctx)
+	if err != nil {
+		return err
+	}
+
+	items, err := c.repo.CountFavoriteItems(ctx)
+	if err != nil {
+		return err
+	}
+
+	topProducts, err := c.repo.GetTopFavoritedProducts(ctx, c.topN)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.totalLists.Set(float64(lists))
+	c.totalItems.Set(float64(items))
+
+	c.topProducts.Reset()
+	for _, product := range topProducts {
+		c.topProducts.WithLabelValues(strconv.Itoa(product.ItemId)).Set(float64(product.FavoriteCount))
+	}
+
+	return nil
+}
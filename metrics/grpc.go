@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCMetrics HTTPMetrics'in gRPC karşılığıdır; method ve status code bazında RED metrikleri toplar.
+type GRPCMetrics struct {
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	inFlight *prometheus.GaugeVec
+}
+
+func NewGRPCMetrics(namespace string, buckets []float64) *GRPCMetrics {
+
+	if len(buckets) == 0 {
+		buckets = prometheus.DefBuckets
+	}
+
+	labels := []string{"method", "code"}
+
+	return &GRPCMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "grpc_requests_total",
+			Help:      "Method ve status code bazında işlenen gRPC istek sayısı.",
+		}, labels),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "grpc_request_errors_total",
+			Help:      "OK dışında bir status code ile sonuçlanan gRPC istek sayısı.",
+		}, labels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "grpc_request_duration_seconds",
+			Help:      "gRPC isteklerinin işlenme süresi.",
+			Buckets:   buckets,
+		}, labels),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "grpc_requests_in_flight",
+			Help:      "Şu anda işlenmekte olan gRPC istek sayısı.",
+		}, []string{"method"}),
+	}
+}
+
+func (m *GRPCMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.requests.Describe(ch)
+	m.errors.Describe(ch)
+	m.duration.Describe(ch)
+	m.inFlight.Describe(ch)
+}
+
+func (m *GRPCMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.requests.Collect(ch)
+	m.errors.Collect(ch)
+	m.duration.Collect(ch)
+	m.inFlight.Collect(ch)
+}
+
+func (m *GRPCMetrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+
+		startTime := time.Now()
+
+		inFlight := m.inFlight.WithLabelValues(info.FullMethod)
+		inFlight.Inc()
+		defer inFlight.Dec()
+
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err)
+
+		m.requests.WithLabelValues(info.FullMethod, code.String()).Inc()
+		m.duration.WithLabelValues(info.FullMethod, code.String()).Observe(time.Since(startTime).Seconds())
+
+		if err != nil {
+			m.errors.WithLabelValues(info.FullMethod, code.String()).Inc()
+		}
+
+		return resp, err
+	}
+}
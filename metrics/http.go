@@ -0,0 +1,115 @@
+package metrics
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// HTTPMetrics, her route şablonu için RED (rate, errors, duration) metriklerini toplar.
+type HTTPMetrics struct {
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	inFlight *prometheus.GaugeVec
+	skip     map[string]struct{}
+}
+
+func NewHTTPMetrics(namespace string, buckets []float64, skipPaths ...string) *HTTPMetrics {
+
+	if len(buckets) == 0 {
+		buckets = prometheus.DefBuckets
+	}
+
+	skip := make(map[string]struct{}, len(skipPaths))
+	for _, path := range skipPaths {
+		skip[path] = struct{}{}
+	}
+
+	labels := []string{"route", "method", "status"}
+
+	return &HTTPMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Route, method ve status bazında işlenen HTTP istek sayısı.",
+		}, labels),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_request_errors_total",
+			Help:      "4xx ve 5xx ile sonuçlanan HTTP istek sayısı.",
+		}, labels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP isteklerinin işlenme süresi.",
+			Buckets:   buckets,
+		}, labels),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "http_requests_in_flight",
+			Help:      "Şu anda işlenmekte olan HTTP istek sayısı.",
+		}, []string{"method"}),
+		skip: skip,
+	}
+}
+
+func (m *HTTPMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.requests.Describe(ch)
+	m.errors.Describe(ch)
+	m.duration.Describe(ch)
+	m.inFlight.Describe(ch)
+}
+
+func (m *HTTPMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.requests.Collect(ch)
+	m.errors.Collect(ch)
+	m.duration.Collect(ch)
+	m.inFlight.Collect(ch)
+}
+
+// Middleware route şablonunu (ör. /lists/:listId) istek tamamlandıktan sonra okur,
+// böylece path parametreleri label kardinalitesini şişirmez.
+func (m *HTTPMetrics) Middleware() fiber.Handler {
+
+	return func(c *fiber.Ctx) error {
+
+		if _, ok := m.skip[c.Path()]; ok {
+			return c.Next()
+		}
+
+		method := c.Method()
+		startTime := time.Now()
+
+		inFlight := m.inFlight.WithLabelValues(method)
+		inFlight.Inc()
+		defer inFlight.Dec()
+
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			status = fiber.StatusInternalServerError
+
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) {
+				status = fiberErr.Code
+			}
+		}
+
+		route := c.Route().Path
+		statusLabel := strconv.Itoa(status)
+
+		m.requests.WithLabelValues(route, method, statusLabel).Inc()
+		m.duration.WithLabelValues(route, method, statusLabel).Observe(time.Since(startTime).Seconds())
+
+		if status >= fiber.StatusBadRequest {
+			m.errors.WithLabelValues(route, method, statusLabel).Inc()
+		}
+
+		return err
+	}
+}
@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPMetricsMiddleware(t *testing.T) {
+
+	httpMetrics := NewHTTPMetrics("test", nil, "/metrics")
+
+	registry := NewRegistry(httpMetrics)
+
+	app := fiber.New()
+
+	app.Use(httpMetrics.Middleware())
+
+	var inFlightDuringRequest float64
+
+	app.Get("/lists/:listId", func(c *fiber.Ctx) error {
+		inFlightDuringRequest = testutil.ToFloat64(httpMetrics.inFlight.WithLabelValues(fiber.MethodGet))
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	app.Delete("/lists/:listId", func(c *fiber.Ctx) error {
+		return fiber.NewError(fiber.StatusNotFound, "Liste bulunamadı")
+	})
+
+	app.Get("/metrics", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	do := func(method string, path string) int {
+
+		response, err := app.Test(httptest.NewRequest(method, path, nil))
+
+		assert.Nil(t, err)
+
+		return response.StatusCode
+	}
+
+	t.Run("TestHTTPMetricsRouteTemplateLabel", func(t *testing.T) {
+
+		assert.Equal(t, fiber.StatusOK, do("GET", "/lists/1"))
+		assert.Equal(t, fiber.StatusOK, do("GET", "/lists/2"))
+
+		assert.Equal(t, float64(2), testutil.ToFloat64(httpMetrics.requests.WithLabelValues("/lists/:listId", "GET", "200")))
+
+		assert.Equal(t, 1, testutil.CollectAndCount(httpMetrics.requests, "test_http_requests_total"))
+
+	})
+
+	t.Run("TestHTTPMetricsInFlight", func(t *testing.T) {
+
+		assert.Equal(t, fiber.StatusOK, do("GET", "/lists/3"))
+
+		assert.Equal(t, float64(1), inFlightDuringRequest)
+
+		assert.Equal(t, float64(0), testutil.ToFloat64(httpMetrics.inFlight.WithLabelValues("GET")))
+
+	})
+
+	t.Run("TestHTTPMetricsErrors", func(t *testing.T) {
+
+		assert.Equal(t, fiber.StatusNotFound, do("DELETE", "/lists/1"))
+
+		assert.Equal(t, float64(1), testutil.ToFloat64(httpMetrics.errors.WithLabelValues("/lists/:listId", "DELETE", "404")))
+
+		assert.Equal(t, float64(0), testutil.ToFloat64(httpMetrics.errors.WithLabelValues("/lists/:listId", "GET", "200")))
+
+	})
+
+	t.Run("TestHTTPMetricsSkipPaths", func(t *testing.T) {
+
+		assert.Equal(t, fiber.StatusOK, do("GET", "/metrics"))
+
+		assert.Equal(t, float64(0), testutil.ToFloat64(httpMetrics.requests.WithLabelValues("/metrics", "GET", "200")))
+
+	})
+
+	t.Run("TestHTTPMetricsRegistry", func(t *testing.T) {
+
+		families, err := registry.Gather()
+
+		assert.Nil(t, err)
+
+		names := []string{}
+		for _, family := range families {
+			names = append(names, family.GetName())
+		}
+
+		assert.Contains(t, names, "test_http_requests_total")
+		assert.Contains(t, names, "test_http_request_errors_total")
+		assert.Contains(t, names, "test_http_request_duration_seconds")
+
+	})
+
+}
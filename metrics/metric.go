@@ -4,11 +4,26 @@ import (
 	"net/http"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
+func NewRegistry(cs ...prometheus.Collector) *prometheus.Registry {
+
+	registry := prometheus.NewRegistry()
+
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	registry.MustRegister(cs...)
+
+	return registry
+}
+
 func GetHandler(registry *prometheus.Registry) http.Handler {
 
-	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry})
 
 }
@@ -0,0 +1,15 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var RateLimitRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "favorite_service",
+	Name:      "rate_limit_rejected_total",
+	Help:      "Rate limit nedeniyle 429 ile reddedilen istek sayısı.",
+}, []string{"rule", "key_type"})
+
+func RateLimitCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		RateLimitRejected,
+	}
+}
@@ -0,0 +1,42 @@
+package psql
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// Transactor, repository çağrılarını context üzerinden taşınan tek bir transaction
+// içinde çalıştırır. İç içe çağrılar dıştaki transaction'a katılır.
+type Transactor struct {
+	db *gorm.DB
+}
+
+func NewTransactor(db *gorm.DB) *Transactor {
+	return &Transactor{
+		db: db,
+	}
+}
+
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	return t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// Conn context'te aktif bir transaction varsa onu, yoksa verilen bağlantıyı döner.
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+
+	return db.WithContext(ctx)
+}
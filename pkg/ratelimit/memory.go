@@ -0,0 +1,101 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	full    time.Time
+}
+
+// MemoryStore, kovaları süreç belleğinde tutar; tek instance'lı kurulumlar içindir.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+
+	now := s.now()
+	capacity := float64(limit.Requests)
+	rate := limit.rate()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updated: now}
+		s.buckets[key] = b
+	}
+
+	b.tokens = math.Min(capacity, b.tokens+now.Sub(b.updated).Seconds()*rate)
+	b.updated = now
+
+	result := Result{Limit: limit.Requests}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsToDuration((1 - b.tokens) / rate)
+	}
+
+	result.Remaining = int(math.Floor(b.tokens))
+	result.ResetAfter = secondsToDuration((capacity - b.tokens) / rate)
+	b.full = now.Add(result.ResetAfter)
+
+	return result, nil
+}
+
+// Sweep tamamen dolmuş kovaları siler; silinen kova bir sonraki istekte
+// dolu olarak yeniden oluşturulacağından davranış değişmez.
+func (s *MemoryStore) Sweep() int {
+
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := 0
+	for key, b := range s.buckets {
+		if !now.Before(b.full) {
+			delete(s.buckets, key)
+			removed++
+		}
+	}
+
+	return removed
+}
+
+func (s *MemoryStore) RunSweeper(ctx context.Context, interval time.Duration) {
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Sweep()
+		}
+	}
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,31 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Limit, Per süresi içinde izin verilen istek sayısını tanımlar. Kova kapasitesi
+// Requests kadardır ve Per süresi boyunca eşit hızla dolar.
+type Limit struct {
+	Requests int
+	Per      time.Duration
+}
+
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Per.Seconds()
+}
+
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration
+	RetryAfter time.Duration
+}
+
+// Store, token bucket durumunu tutar. Birden fazla instance'ın aynı limiti
+// paylaşması gerektiğinde Redis gibi ortak bir backend ile değiştirilebilir.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
@@ -0,0 +1,20 @@
+package requestid
+
+import "context"
+
+type requestIdKey struct{}
+
+// NewContext isteğin id'sini context'e ekler; servis katmanı audit kayıtlarında kullanır.
+func NewContext(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIdKey{}, requestId)
+}
+
+// FromContext context'teki istek id'sini döner, yoksa boş string döner.
+func FromContext(ctx context.Context) string {
+
+	if requestId, ok := ctx.Value(requestIdKey{}).(string); ok {
+		return requestId
+	}
+
+	return ""
+}
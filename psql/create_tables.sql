@@ -2,14 +2,147 @@ CREATE TABLE FavoriteList(
     id serial PRIMARY KEY NOT NULL,
     listname VARCHAR(100) NOT NULL,
     createddate TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    userid INT NOT NULL
+    userid INT NOT NULL,
+    description VARCHAR(500) NOT NULL DEFAULT '',
+    visibility VARCHAR(20) NOT NULL DEFAULT 'private',
+    listtype VARCHAR(30) NOT NULL DEFAULT 'wishlist',
+    emoji VARCHAR(16) NOT NULL DEFAULT '',
+    coverimageurl VARCHAR(500) NOT NULL DEFAULT '',
+    updateddate TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    isdefault BOOLEAN NOT NULL DEFAULT FALSE,
+    version INT NOT NULL DEFAULT 1
 );
 
+-- Her kullanıcının en fazla bir varsayılan listesi olabilir.
+CREATE UNIQUE INDEX idx_favoritelist_default ON FavoriteList(userid) WHERE isdefault;
+
 
 CREATE TABLE FavoriteItem(
     itemid INT NOT NULL,
     listid INT NOT NULL,
     createddate TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    favoritedprice NUMERIC(12,2),
+    favoritedstock INT,
+    position INT NOT NULL DEFAULT 0,
+    note VARCHAR(500) NOT NULL DEFAULT '',
+    quantity INT NOT NULL DEFAULT 1,
+    priority VARCHAR(20) NOT NULL DEFAULT 'nice_to_have',
+    variant VARCHAR(100) NOT NULL DEFAULT '',
     PRIMARY KEY (listid, itemid),
     FOREIGN KEY (listid) REFERENCES FavoriteList(id)
-);
\ No newline at end of file
+);
+
+CREATE MATERIALIZED VIEW FavoriteItemDailyCount AS
+SELECT itemid, CAST(createddate AS DATE) AS day, COUNT(*) AS favoritecount
+FROM FavoriteItem
+GROUP BY itemid, CAST(createddate AS DATE);
+
+CREATE UNIQUE INDEX idx_favoriteitemdailycount_itemid_day ON FavoriteItemDailyCount(itemid, day);
+
+CREATE INDEX idx_favoritelist_userid ON FavoriteList(userid);
+
+CREATE INDEX idx_favoriteitem_itemid ON FavoriteItem(itemid);
+
+
+CREATE TABLE ProductWatch(
+    itemid INT PRIMARY KEY NOT NULL,
+    lastprice NUMERIC(12,2) NOT NULL,
+    laststock INT NOT NULL,
+    checkeddate TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+
+CREATE TABLE ProductAlert(
+    id serial PRIMARY KEY NOT NULL,
+    userid INT NOT NULL,
+    listid INT NOT NULL,
+    itemid INT NOT NULL,
+    alerttype VARCHAR(20) NOT NULL,
+    oldprice NUMERIC(12,2),
+    newprice NUMERIC(12,2),
+    oldstock INT,
+    newstock INT,
+    createddate TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_productalert_userid_createddate ON ProductAlert(userid, createddate DESC);
+
+
+CREATE TABLE OutboxEvent(
+    id UUID PRIMARY KEY NOT NULL,
+    eventtype VARCHAR(100) NOT NULL,
+    version INT NOT NULL,
+    payload JSONB NOT NULL,
+    createddate TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    publisheddate TIMESTAMP,
+    attempts INT NOT NULL DEFAULT 0,
+    lasterror TEXT,
+    nextattemptdate TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deadletterdate TIMESTAMP
+);
+
+CREATE INDEX idx_outboxevent_pending ON OutboxEvent(createddate, id) WHERE publisheddate IS NULL AND deadletterdate IS NULL;
+
+
+CREATE TABLE WebhookSubscription(
+    id serial PRIMARY KEY NOT NULL,
+    url VARCHAR(2048) NOT NULL,
+    eventtypes TEXT NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    createddate TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+
+CREATE TABLE WebhookDelivery(
+    id serial PRIMARY KEY NOT NULL,
+    subscriptionid INT NOT NULL,
+    eventid UUID NOT NULL,
+    eventtype VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    nextattemptdate TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    laststatuscode INT,
+    lasterror TEXT,
+    createddate TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updateddate TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (subscriptionid, eventid),
+    FOREIGN KEY (subscriptionid) REFERENCES WebhookSubscription(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_webhookdelivery_due ON WebhookDelivery(nextattemptdate) WHERE status = 'pending';
+
+CREATE TABLE IdempotencyKey(
+    scope VARCHAR(64) NOT NULL,
+    idempotencykey VARCHAR(255) NOT NULL,
+    fingerprint VARCHAR(64) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'in_progress',
+    responsestatus INT,
+    contenttype VARCHAR(255),
+    responsebody BYTEA,
+    createddate TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    expiresat TIMESTAMP NOT NULL,
+    PRIMARY KEY (scope, idempotencykey)
+);
+
+CREATE INDEX idx_idempotencykey_expiresat ON IdempotencyKey(expiresat);
+
+
+-- AuditLog yalnızca eklenir; liste ve ürün değişikliklerinin geçmişini tutar.
+CREATE TABLE AuditLog(
+    id BIGSERIAL PRIMARY KEY NOT NULL,
+    actoruserid INT NOT NULL,
+    requestid VARCHAR(64) NOT NULL DEFAULT '',
+    entitytype VARCHAR(20) NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    listid INT NOT NULL,
+    itemid INT,
+    before JSONB,
+    after JSONB,
+    createddate TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_auditlog_listid ON AuditLog(listid, id DESC);
+
+CREATE INDEX idx_auditlog_actoruserid ON AuditLog(actoruserid, id DESC);